@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,10 +13,16 @@ import (
 
 	"egot-tracker/internal/config"
 	"egot-tracker/internal/database"
+	"egot-tracker/internal/gql"
 	"egot-tracker/internal/handler"
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/metrics"
+	"egot-tracker/internal/openapi"
 	"egot-tracker/internal/repository"
+	"egot-tracker/internal/requestid"
 	"egot-tracker/internal/scraper"
 	"egot-tracker/internal/service"
+	"egot-tracker/internal/webhook"
 	"egot-tracker/pkg/response"
 )
 
@@ -25,7 +31,8 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "Deprecation, Sunset, Link, X-Request-ID")
 
 		// Handle preflight requests
 		if r.Method == "OPTIONS" {
@@ -37,6 +44,12 @@ func corsMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
 	// Load .env file if present
 	godotenv.Load()
@@ -44,9 +57,13 @@ func main() {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("failed to load config", err)
 	}
 
+	// Log JSON lines at the configured level
+	logger := logging.New(os.Stdout, cfg.LogLevel)
+	slog.SetDefault(logger)
+
 	// Create context for database connection
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -54,33 +71,103 @@ func main() {
 	// Initialize database connection pool
 	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", err)
 	}
 	defer pool.Close()
 
-	log.Println("Connected to database")
+	logger.Info("connected to database")
+	database.RegisterPoolMetrics(pool)
+
+	// Refuse to serve against a schema missing migrations this binary expects
+	if cfg.CheckSchema {
+		if err := database.CheckSchema(ctx, pool); err != nil {
+			fatal("schema check failed; run `go run ./cmd/migrate up`, or set CHECK_SCHEMA=false", err)
+		}
+	}
 
 	// Initialize repositories
 	celebrityRepo := repository.NewCelebrityRepository(pool)
 	awardRepo := repository.NewAwardRepository(pool)
+	aliasRepo := repository.NewAliasRepository(pool)
+	uow := repository.NewUnitOfWork(pool)
 	oscarRepo := repository.NewOscarRepository(pool)
+	statsRepo := repository.NewStatsRepository(pool)
+	workRepo := repository.NewWorkRepository(pool)
+	apiKeyRepo := repository.NewAPIKeyRepository(pool)
+	watchlistRepo := repository.NewWatchlistRepository(pool)
+	webhookRepo := repository.NewWebhookRepository(pool)
+	eventRepo := repository.NewEventRepository(pool)
+	ceremonyRepo := repository.NewCeremonyRepository(pool)
 
 	// Initialize Wikidata scraper
 	wikidataScraper := scraper.NewWikidataScraper()
 
 	// Initialize services
-	celebrityService := service.NewCelebrityService(celebrityRepo, awardRepo, wikidataScraper)
+	celebrityService := service.NewCelebrityService(celebrityRepo, awardRepo, aliasRepo, uow, wikidataScraper)
 	oscarService := service.NewOscarService(oscarRepo, celebrityRepo)
+	statsService := service.NewStatsService(statsRepo, celebrityRepo, awardRepo)
+	workService := service.NewWorkService(workRepo)
+	collaboratorService := service.NewCollaboratorService(celebrityRepo, workRepo)
+	watchlistService := service.NewWatchlistService(apiKeyRepo, watchlistRepo, webhookRepo, celebrityRepo)
+	eventService := service.NewEventService(eventRepo)
+	feedService := service.NewFeedService(awardRepo, celebrityRepo, eventRepo, cfg.SiteURL)
+	calendarService := service.NewCalendarService(ceremonyRepo, watchlistRepo, cfg.SiteURL)
+	graphqlExecutor, err := gql.NewExecutor(&gql.Repositories{
+		Celebrities: celebrityRepo,
+		Awards:      awardRepo,
+		Aliases:     aliasRepo,
+		Works:       workRepo,
+		Oscars:      oscarRepo,
+	}, celebrityService)
+	if err != nil {
+		fatal("failed to build GraphQL schema", err)
+	}
+
+	// Rebuild the collaborator graph whenever awards change, including
+	// changes made by other processes such as populate
+	listenCtx, stopListening := context.WithCancel(context.Background())
+	defer stopListening()
+	go database.Listen(listenCtx, pool, database.AwardsChangedChannel, collaboratorService.Invalidate)
+
+	// Send webhook deliveries queued by award changes
+	go webhook.NewDispatcher(webhookRepo).Run(listenCtx)
+
+	// Scrape watched and stale celebrities again, so new awards are saved
+	// and their watchers notified
+	if cfg.RefreshEnabled {
+		go service.NewRefresher(celebrityService).Run(listenCtx)
+	}
 
 	// Initialize handlers
 	celebrityHandler := handler.NewCelebrityHandler(celebrityService)
 	oscarHandler := handler.NewOscarHandler(oscarService)
+	statsHandler := handler.NewStatsHandler(statsService)
+	workHandler := handler.NewWorkHandler(workService)
+	collaboratorHandler := handler.NewCollaboratorHandler(collaboratorService)
+	watchlistHandler := handler.NewWatchlistHandler(watchlistService)
+	eventHandler := handler.NewEventHandler(eventService)
+	feedHandler := handler.NewFeedHandler(feedService)
+	calendarHandler := handler.NewCalendarHandler(calendarService)
+	graphqlHandler := handler.NewGraphQLHandler(graphqlExecutor)
+	requireAPIKey := func(next http.HandlerFunc) http.HandlerFunc {
+		return handler.RequireAPIKey(watchlistService, next)
+	}
+	requireAdminKey := func(next http.HandlerFunc) http.HandlerFunc {
+		return handler.RequireAdminKey(watchlistService, next)
+	}
 
-	// Setup routes
+	// Setup routes. Every route is documented in the OpenAPI document, and
+	// in development requests and responses are checked against it.
 	mux := http.NewServeMux()
+	router := openapi.NewRouter(mux, openapi.Config{
+		Info:       handler.APIInfo,
+		Operations: handler.Operations,
+		Enums:      handler.Enums,
+		Validate:   cfg.Development,
+	})
 
 	// Health check endpoint
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+	router.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
 		if err := database.HealthCheck(r.Context(), pool); err != nil {
 			response.Error(w, http.StatusServiceUnavailable, "database unhealthy")
 			return
@@ -88,30 +175,47 @@ func main() {
 		response.JSON(w, http.StatusOK, map[string]string{"status": "OK"})
 	})
 
-	// Celebrity search endpoint
-	mux.HandleFunc("GET /api/celebrity/search", celebrityHandler.Search)
+	// JSON API, served under /api/v1 and, until its sunset, the deprecated
+	// unversioned /api prefix
+	api := apiHandlers{
+		celebrity:       celebrityHandler,
+		oscar:           oscarHandler,
+		stats:           statsHandler,
+		work:            workHandler,
+		collaborator:    collaboratorHandler,
+		watchlist:       watchlistHandler,
+		event:           eventHandler,
+		requireAPIKey:   requireAPIKey,
+		requireAdminKey: requireAdminKey,
+	}
+	registerAPIRoutes(router.WithPrefix("/api/v1"), api)
+	registerAPIRoutes(router.WithPrefix("/api").Deprecated(legacyAPI), api)
 
-	// Celebrity autocomplete endpoint
-	mux.HandleFunc("GET /api/celebrity/autocomplete", celebrityHandler.Autocomplete)
+	// Atom and RSS feeds
+	router.HandleFunc("GET /feeds/awards.atom", feedHandler.RecentAwards)
+	router.HandleFunc("GET /feeds/awards.rss", feedHandler.RecentAwards)
+	router.HandleFunc("GET /feeds/egot.atom", feedHandler.CompletedEGOTs)
+	router.HandleFunc("GET /feeds/egot.rss", feedHandler.CompletedEGOTs)
+	router.HandleFunc("GET /feeds/celebrity/{file}", feedHandler.Celebrity)
 
-	// Close to EGOT endpoint
-	mux.HandleFunc("GET /api/celebrity/close-to-egot", celebrityHandler.CloseToEGOT)
+	// iCalendar feed of upcoming ceremonies
+	router.HandleFunc("GET /calendar/ceremonies.ics", calendarHandler.Ceremonies)
 
-	// EGOT winners endpoint
-	mux.HandleFunc("GET /api/celebrity/egot-winners", celebrityHandler.EGOTWinners)
+	// Prometheus metrics, kept out of the OpenAPI document
+	mux.Handle("GET /metrics", metrics.Default)
 
-	// No awards endpoint
-	mux.HandleFunc("GET /api/celebrity/no-awards", celebrityHandler.NoAwards)
+	// OpenAPI document
+	router.HandleFunc("GET /openapi.json", router.ServeDocument)
 
-	// Oscar race endpoints
-	mux.HandleFunc("GET /api/oscar-race/years", oscarHandler.GetYears)
-	mux.HandleFunc("GET /api/oscar-race/{year}", oscarHandler.GetCeremony)
-	mux.HandleFunc("PUT /api/oscar-race/{year}/category/{categoryId}/winner/{nomineeId}", oscarHandler.SetWinner)
+	// GraphQL
+	router.HandleFunc("GET /graphql", graphqlHandler.Query)
+	router.HandleFunc("POST /graphql", graphqlHandler.Query)
 
-	// Create server with CORS middleware
+	// Create server with CORS, request ID, request logging and metrics middleware
 	server := &http.Server{
 		Addr:         ":" + cfg.Port,
-		Handler:      corsMiddleware(mux),
+		Handler:      corsMiddleware(requestid.Middleware(logging.Middleware(logger)(metrics.Middleware(router)))),
+		ErrorLog:     slog.NewLogLogger(logger.Handler(), slog.LevelError),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -119,9 +223,9 @@ func main() {
 
 	// Start server in goroutine
 	go func() {
-		log.Printf("Server starting on port %s", cfg.Port)
+		logger.Info("server starting", "port", cfg.Port)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed: %v", err)
+			fatal("server failed", err)
 		}
 	}()
 
@@ -130,15 +234,15 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	log.Println("Shutting down server...")
+	logger.Info("shutting down server")
 
 	// Graceful shutdown with timeout
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer shutdownCancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Fatalf("Server forced to shutdown: %v", err)
+		fatal("server forced to shut down", err)
 	}
 
-	log.Println("Server stopped")
+	logger.Info("server stopped")
 }
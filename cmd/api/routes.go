@@ -0,0 +1,92 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"egot-tracker/internal/handler"
+	"egot-tracker/internal/openapi"
+)
+
+// apiHandlers are the handlers behind the versioned JSON API
+type apiHandlers struct {
+	celebrity     *handler.CelebrityHandler
+	oscar         *handler.OscarHandler
+	stats         *handler.StatsHandler
+	work          *handler.WorkHandler
+	collaborator  *handler.CollaboratorHandler
+	watchlist     *handler.WatchlistHandler
+	event         *handler.EventHandler
+	requireAPIKey func(http.HandlerFunc) http.HandlerFunc
+	// requireAdminKey guards routes that change data everyone sees
+	requireAdminKey func(http.HandlerFunc) http.HandlerFunc
+}
+
+// legacyAPI deprecates the unversioned /api routes in favour of /api/v1
+var legacyAPI = openapi.Deprecation{
+	Since:     time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC),
+	Sunset:    time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC),
+	Successor: "/api/v1",
+}
+
+// registerAPIRoutes registers the JSON API under the router's prefix. It is
+// called once for each prefix the API is served under, so every version
+// exposes the same routes.
+func registerAPIRoutes(router *openapi.Router, h apiHandlers) {
+	// Faceted celebrity browse endpoint
+	router.HandleFunc("GET /celebrities", h.celebrity.Browse)
+
+	// Celebrity search endpoint
+	router.HandleFunc("GET /celebrity/search", h.celebrity.Search)
+
+	// Celebrity autocomplete endpoint
+	router.HandleFunc("GET /celebrity/autocomplete", h.celebrity.Autocomplete)
+
+	// Close to EGOT endpoint
+	router.HandleFunc("GET /celebrity/close-to-egot", h.celebrity.CloseToEGOT)
+
+	// EGOT winners endpoint
+	router.HandleFunc("GET /celebrity/egot-winners", h.celebrity.EGOTWinners)
+
+	// No awards endpoint
+	router.HandleFunc("GET /celebrity/no-awards", h.celebrity.NoAwards)
+
+	// Upcoming nominations that would complete an EGOT or reach 3/4
+	router.HandleFunc("GET /egot-watch", h.celebrity.EGOTWatch)
+
+	// Celebrity alias endpoints
+	router.HandleFunc("GET /celebrity/{id}/aliases", h.celebrity.GetAliases)
+	router.HandleFunc("POST /celebrity/{id}/aliases", h.requireAdminKey(h.celebrity.AddAlias))
+
+	// Work endpoint
+	router.HandleFunc("GET /works/{id}", h.work.GetWork)
+
+	// Collaborator endpoints
+	router.HandleFunc("GET /celebrity/{id}/collaborators", h.collaborator.GetCollaborators)
+	router.HandleFunc("GET /path", h.collaborator.GetPath)
+
+	// Watchlist endpoints (require an API key)
+	router.HandleFunc("GET /watchlists", h.requireAPIKey(h.watchlist.List))
+	router.HandleFunc("POST /watchlists", h.requireAPIKey(h.watchlist.Create))
+	router.HandleFunc("GET /watchlists/{id}", h.requireAPIKey(h.watchlist.Get))
+	router.HandleFunc("DELETE /watchlists/{id}", h.requireAPIKey(h.watchlist.Delete))
+	router.HandleFunc("POST /watchlists/{id}/calendar-token", h.requireAPIKey(h.watchlist.CalendarToken))
+	router.HandleFunc("PUT /watchlists/{id}/celebrities/{celebrityId}", h.requireAPIKey(h.watchlist.AddCelebrity))
+	router.HandleFunc("DELETE /watchlists/{id}/celebrities/{celebrityId}", h.requireAPIKey(h.watchlist.RemoveCelebrity))
+	router.HandleFunc("GET /watchlists/{id}/deliveries", h.requireAPIKey(h.watchlist.Deliveries))
+
+	// Oscar race endpoints
+	router.HandleFunc("GET /oscar-race/years", h.oscar.GetYears)
+	router.HandleFunc("GET /oscar-race/{year}", h.oscar.GetCeremony)
+	router.HandleFunc("PUT /oscar-race/{year}/category/{categoryId}/winner/{nomineeId}", h.oscar.SetWinner)
+
+	// Stats endpoints
+	router.HandleFunc("GET /stats", h.stats.GetStats)
+	router.HandleFunc("GET /stats/egot-timeline", h.stats.EGOTTimeline)
+
+	// Leaderboard endpoint
+	router.HandleFunc("GET /leaderboards/{metric}", h.stats.Leaderboard)
+
+	// EGOT milestone events
+	router.HandleFunc("GET /events", h.event.List)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"egot-tracker/internal/config"
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/repository"
+	"egot-tracker/internal/service"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: apikey -name NAME [-admin] create|revoke")
+	flag.PrintDefaults()
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// Manages API keys for the authenticated endpoints. create issues a new key
+// and prints it once; revoke revokes every active key with the name.
+func main() {
+	name := flag.String("name", "", "Name identifying the key's owner")
+	admin := flag.Bool("admin", false, "Let the key curate data, such as adding celebrity aliases")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 1 || *name == "" {
+		usage()
+		os.Exit(2)
+	}
+
+	godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	ctx := context.Background()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+	defer pool.Close()
+
+	watchlistService := service.NewWatchlistService(
+		repository.NewAPIKeyRepository(pool),
+		repository.NewWatchlistRepository(pool),
+		repository.NewWebhookRepository(pool),
+		repository.NewCelebrityRepository(pool),
+	)
+
+	switch flag.Arg(0) {
+	case "create":
+		_, key, err := watchlistService.CreateAPIKey(ctx, *name, *admin)
+		if err != nil {
+			fatal("failed to create API key", err)
+		}
+		slog.Info("created API key; store it now, it cannot be shown again", "name", *name, "admin", *admin)
+		fmt.Println(key)
+	case "revoke":
+		revoked, err := watchlistService.RevokeAPIKey(ctx, *name)
+		if err != nil {
+			fatal("failed to revoke API key", err)
+		}
+		slog.Info("revoked API keys", "name", *name, "count", revoked)
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"egot-tracker/internal/config"
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+	"egot-tracker/internal/scraper"
+)
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// One-off backfill linking awards imported before works were tracked. The
+// work named on each award's Wikidata statement is looked up, saved to the
+// works table, and linked to the award. Awards without a statement ID (seed
+// and manual rows) are then linked to a known work with the same title, if
+// exactly one exists; the rest stay unlinked.
+func main() {
+	batchSize := flag.Int("batch-size", 200, "Statements to look up per SPARQL query")
+	flag.Parse()
+
+	godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	ctx := context.Background()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+	defer pool.Close()
+
+	awardRepo := repository.NewAwardRepository(pool)
+	workRepo := repository.NewWorkRepository(pool)
+	wikidataScraper := scraper.NewWikidataScraper()
+
+	statementIDs, err := awardRepo.FindStatementsWithoutWork(ctx)
+	if err != nil {
+		fatal("failed to find awards without works", err)
+	}
+	slog.Info("looking up works for award statements", "statements", len(statementIDs))
+
+	for start := 0; start < len(statementIDs); start += *batchSize {
+		end := min(start+*batchSize, len(statementIDs))
+
+		found, err := wikidataScraper.GetStatementWorks(ctx, statementIDs[start:end])
+		if err != nil {
+			fatal("failed to look up works", err)
+		}
+
+		works := make([]models.Work, 0, len(found))
+		workIDs := make(map[string]string, len(found))
+		for statementID, work := range found {
+			works = append(works, models.Work{
+				WikidataID: work.WorkID,
+				Title:      work.Title,
+				Type:       work.Type,
+			})
+			workIDs[statementID] = work.WorkID
+		}
+
+		if err := workRepo.UpsertBatch(ctx, works); err != nil {
+			fatal("failed to save works", err)
+		}
+		if err := awardRepo.SetWikidataWorkIDs(ctx, workIDs); err != nil {
+			fatal("failed to record work IDs", err)
+		}
+		slog.Info("found works", "progress", end, "total", len(statementIDs), "statements", len(found))
+	}
+
+	linked, err := workRepo.LinkAwards(ctx)
+	if err != nil {
+		fatal("failed to link awards to works", err)
+	}
+	slog.Info("linked awards to works", "awards", linked)
+
+	linkedByTitle, err := workRepo.LinkAwardsByTitle(ctx)
+	if err != nil {
+		fatal("failed to link awards to works by title", err)
+	}
+	slog.Info("linked awards without statements to works by title", "awards", linkedByTitle)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"egot-tracker/internal/config"
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/repository"
+)
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// One-off cleanup for awards duplicated by repeated imports. For every natural
+// key (celebrity, type, category, year, work) a single row is kept, preferring
+// winners, rows with a Wikidata statement, and the most recently fetched row.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "Report duplicates without deleting them")
+	flag.Parse()
+
+	godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	ctx := context.Background()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+	defer pool.Close()
+
+	awardRepo := repository.NewAwardRepository(pool)
+
+	count, err := awardRepo.CountDuplicates(ctx)
+	if err != nil {
+		fatal("failed to count duplicate awards", err)
+	}
+	slog.Info("found duplicate award rows", "rows", count)
+
+	if *dryRun || count == 0 {
+		return
+	}
+
+	deleted, err := awardRepo.DeleteDuplicates(ctx)
+	if err != nil {
+		fatal("failed to delete duplicate awards", err)
+	}
+	slog.Info("deleted duplicate award rows", "rows", deleted)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"egot-tracker/internal/config"
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/snapshot"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: export [-format jsonl|csv] PATH")
+	fmt.Fprintln(os.Stderr, "PATH is a file (or - for stdout) for jsonl, and a directory for csv.")
+	flag.PrintDefaults()
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// Writes a versioned snapshot of celebrities, aliases, works, awards and the
+// Oscar race that cmd/import can restore into any database at the current
+// schema version
+func main() {
+	format := flag.String("format", "jsonl", "Snapshot format: jsonl or csv")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 1 || (*format != "jsonl" && *format != "csv") {
+		usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	if *format == "csv" && path == "-" {
+		fmt.Fprintln(os.Stderr, "CSV snapshots are written to a directory, not stdout")
+		os.Exit(2)
+	}
+
+	godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	ctx := context.Background()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+	defer pool.Close()
+
+	s, err := snapshot.Export(ctx, pool)
+	if err != nil {
+		fatal("export failed", err)
+	}
+
+	switch {
+	case *format == "csv":
+		err = snapshot.WriteCSV(path, s)
+	case path == "-":
+		err = snapshot.WriteJSONL(os.Stdout, s)
+	default:
+		err = writeFile(path, s)
+	}
+	if err != nil {
+		fatal("failed to write snapshot", err)
+	}
+
+	for _, table := range snapshot.TableNames() {
+		slog.Info("exported", "table", table, "rows", s.Header.Counts[table])
+	}
+}
+
+func writeFile(path string, s *snapshot.Snapshot) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := snapshot.WriteJSONL(f, s); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/joho/godotenv"
+
+	"egot-tracker/internal/config"
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/repository"
+	"egot-tracker/internal/service"
+	"egot-tracker/internal/snapshot"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: import [-merge] [-dry-run] PATH")
+	fmt.Fprintln(os.Stderr, "PATH is a jsonl file (or - for stdin) or a csv snapshot directory.")
+	flag.PrintDefaults()
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// Restores a snapshot written by cmd/export. The whole snapshot is
+// validated before anything is written, and it is written in one
+// transaction.
+func main() {
+	merge := flag.Bool("merge", false, "Upsert by ID into a database that already has data")
+	dryRun := flag.Bool("dry-run", false, "Validate and write the snapshot, then roll back")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	s, err := readSnapshot(flag.Arg(0))
+	if err != nil {
+		fatal("failed to read snapshot", err)
+	}
+	slog.Info("read snapshot",
+		"version", s.Header.Version,
+		"schema_version", s.Header.SchemaVersion,
+		"exported_at", s.Header.ExportedAt,
+	)
+
+	ctx := context.Background()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+	defer pool.Close()
+
+	written, err := snapshot.Import(ctx, pool, s, snapshot.ImportOptions{
+		Merge:       *merge,
+		DryRun:      *dryRun,
+		TrackAwards: trackAwards,
+	})
+	var invalid *snapshot.ValidationError
+	if errors.As(err, &invalid) {
+		slog.Error("invalid snapshot", "problems", invalid.Problems)
+		os.Exit(1)
+	}
+	if err != nil {
+		fatal("import failed", err)
+	}
+
+	msg := "imported"
+	if *dryRun {
+		msg = "dry run: would import"
+	}
+	for _, table := range snapshot.TableNames() {
+		slog.Info(msg, "table", table, "rows", written[table])
+	}
+}
+
+// trackAwards records milestones reached and queues webhooks for watchers
+// of the celebrities whose awards the import changes, as a scrape would
+func trackAwards(ctx context.Context, tx pgx.Tx, ids []string, write func() error) error {
+	celebrityIDs := make([]pgtype.UUID, len(ids))
+	for i, id := range ids {
+		if err := celebrityIDs[i].Scan(id); err != nil {
+			return err
+		}
+	}
+	return service.TrackAwardChanges(ctx, repository.NewRepositories(tx), celebrityIDs, write)
+}
+
+// readSnapshot reads a CSV snapshot from a directory, and JSON lines
+// otherwise
+func readSnapshot(path string) (*snapshot.Snapshot, error) {
+	if path == "-" {
+		return snapshot.ReadJSONL(os.Stdin)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return snapshot.ReadCSV(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return snapshot.ReadJSONL(f)
+}
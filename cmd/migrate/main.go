@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"egot-tracker/internal/config"
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: migrate [-steps N] up|down|status")
+	flag.PrintDefaults()
+}
+
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+func main() {
+	steps := flag.Int("steps", 1, "Number of migrations to revert with down")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	godotenv.Load()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fatal("failed to load config", err)
+	}
+
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
+	ctx := context.Background()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+	defer pool.Close()
+
+	switch flag.Arg(0) {
+	case "up":
+		applied, err := database.MigrateUp(ctx, pool)
+		for _, m := range applied {
+			slog.Info("applied migration", "version", m.Version, "name", m.Name)
+		}
+		if err != nil {
+			fatal("migration failed", err)
+		}
+		if len(applied) == 0 {
+			slog.Info("schema is up to date")
+		}
+
+	case "down":
+		reverted, err := database.MigrateDown(ctx, pool, *steps)
+		for _, m := range reverted {
+			slog.Info("reverted migration", "version", m.Version, "name", m.Name)
+		}
+		if err != nil {
+			fatal("migration failed", err)
+		}
+		if len(reverted) == 0 {
+			slog.Info("no migrations to revert")
+		}
+
+	case "status":
+		statuses, err := database.GetMigrationStatus(ctx, pool)
+		if err != nil {
+			fatal("failed to read migration status", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, state)
+		}
+
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
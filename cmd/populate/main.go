@@ -2,13 +2,15 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/joho/godotenv"
 
 	"egot-tracker/internal/config"
 	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
 	"egot-tracker/internal/repository"
 	"egot-tracker/internal/scraper"
 	"egot-tracker/internal/service"
@@ -348,41 +350,52 @@ var celebrities = []string{
 	"Zoe Saldaña",
 }
 
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
 	godotenv.Load()
 
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("failed to load config", err)
 	}
 
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
 	// Use a longer timeout for the entire operation
 	ctx := context.Background()
 
 	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", err)
 	}
 	defer pool.Close()
 
 	celebrityRepo := repository.NewCelebrityRepository(pool)
 	awardRepo := repository.NewAwardRepository(pool)
+	aliasRepo := repository.NewAliasRepository(pool)
+	uow := repository.NewUnitOfWork(pool)
 	wikidataScraper := scraper.NewWikidataScraper()
-	celebrityService := service.NewCelebrityService(celebrityRepo, awardRepo, wikidataScraper)
+	celebrityService := service.NewCelebrityService(celebrityRepo, awardRepo, aliasRepo, uow, wikidataScraper)
 
 	successCount := 0
 	skipCount := 0
 	failCount := 0
 
-	log.Printf("Starting population of %d celebrities...\n", len(celebrities))
+	slog.Info("starting population", "celebrities", len(celebrities))
 
 	for i, name := range celebrities {
-		log.Printf("[%d/%d] Processing: %s", i+1, len(celebrities), name)
+		logger := slog.With("celebrity", name, "progress", i+1, "total", len(celebrities))
 
 		// Check if already exists
 		existing, _ := celebrityRepo.FindByName(ctx, name)
 		if existing != nil {
-			log.Printf("  → Skipped (already exists)")
+			logger.Info("skipped, already exists")
 			skipCount++
 			continue
 		}
@@ -395,10 +408,10 @@ func main() {
 		cancel()
 
 		if err != nil {
-			log.Printf("  → Failed: %v", err)
+			logger.Warn("failed", "error", err)
 			failCount++
 		} else {
-			log.Printf("  → Success: %d awards found", len(result.Awards))
+			logger.Info("populated", "awards", len(result.Awards))
 			successCount++
 		}
 
@@ -408,9 +421,10 @@ func main() {
 		}
 	}
 
-	log.Println("\n=== Population Complete ===")
-	log.Printf("Success: %d", successCount)
-	log.Printf("Skipped: %d", skipCount)
-	log.Printf("Failed:  %d", failCount)
-	log.Printf("Total:   %d", len(celebrities))
+	slog.Info("population complete",
+		"success", successCount,
+		"skipped", skipCount,
+		"failed", failCount,
+		"total", len(celebrities),
+	)
 }
@@ -3,7 +3,9 @@ package main
 import (
 	"context"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
+	"os"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -11,12 +13,19 @@ import (
 
 	"egot-tracker/internal/config"
 	"egot-tracker/internal/database"
+	"egot-tracker/internal/logging"
 	"egot-tracker/internal/models"
 	"egot-tracker/internal/repository"
 	"egot-tracker/internal/scraper"
 	"egot-tracker/internal/service"
 )
 
+// fatal logs err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
 func main() {
 	// Parse flags
 	year := flag.Int("year", 2025, "Oscar ceremony year")
@@ -27,14 +36,17 @@ func main() {
 
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("Failed to load config: %v", err)
+		fatal("failed to load config", err)
 	}
 
+	// Log to stderr at the configured level
+	slog.SetDefault(logging.New(os.Stderr, cfg.LogLevel))
+
 	ctx := context.Background()
 
 	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", err)
 	}
 	defer pool.Close()
 
@@ -44,18 +56,19 @@ func main() {
 	oscarService := service.NewOscarService(oscarRepo, celebrityRepo)
 	wikiScraper := scraper.NewWikipediaScraper()
 
-	log.Printf("Setting up Oscar race for %d...\n", *year)
+	slog.Info("setting up Oscar race", "year", *year)
 
 	// Check if ceremony already exists
 	existing, _ := oscarRepo.GetCeremonyByYear(ctx, *year)
 	if existing != nil {
 		if *reset {
-			log.Printf("Deleting existing ceremony data for %d...", *year)
+			slog.Info("deleting existing ceremony data", "year", *year)
 			if err := oscarService.DeleteCeremony(ctx, *year); err != nil {
-				log.Fatalf("Failed to delete existing ceremony: %v", err)
+				fatal("failed to delete existing ceremony", err)
 			}
 		} else {
-			log.Fatalf("Ceremony for %d already exists. Use --reset to recreate.", *year)
+			slog.Error("ceremony already exists; use -reset to recreate it", "year", *year)
+			os.Exit(1)
 		}
 	}
 
@@ -64,7 +77,8 @@ func main() {
 	if *year == 2025 {
 		nominations = scraper.GetOscarNominations2025()
 	} else {
-		log.Fatalf("No nomination data available for year %d. Only 2025 is currently supported.", *year)
+		slog.Error("no nomination data for this year; only 2025 is supported", "year", *year)
+		os.Exit(1)
 	}
 
 	// Create ceremony
@@ -78,9 +92,9 @@ func main() {
 		IsComplete: false,
 	})
 	if err != nil {
-		log.Fatalf("Failed to create ceremony: %v", err)
+		fatal("failed to create ceremony", err)
 	}
-	log.Printf("Created ceremony: %s\n", ceremonyName)
+	slog.Info("created ceremony", "ceremony", ceremonyName)
 
 	// Track stats
 	categoriesCreated := 0
@@ -97,11 +111,11 @@ func main() {
 			WinnerAnnounced: false,
 		})
 		if err != nil {
-			log.Printf("Failed to create category %s: %v", nom.Category, err)
+			slog.Error("failed to create category", "category", nom.Category, "error", err)
 			continue
 		}
 		categoriesCreated++
-		log.Printf("  Category: %s (%d nominees)", nom.Category, len(nom.Nominees))
+		slog.Info("created category", "category", nom.Category, "nominees", len(nom.Nominees))
 
 		// Create nominees
 		for j, nomineeInfo := range nom.Nominees {
@@ -125,7 +139,7 @@ func main() {
 				// Try to fetch from Wikipedia
 				summary, err := wikiScraper.FetchPersonSummary(ctx, nomineeInfo.Name)
 				if err != nil {
-					log.Printf("    Warning: Could not fetch Wikipedia data for %s: %v", nomineeInfo.Name, err)
+					slog.Warn("could not fetch Wikipedia data", "nominee", nomineeInfo.Name, "error", err)
 				}
 
 				photoURL := ""
@@ -140,7 +154,7 @@ func main() {
 				// Find or create celebrity
 				celebrity, err := oscarService.FindOrCreateCelebrity(ctx, nomineeInfo.Name, photoURL, bio)
 				if err != nil {
-					log.Printf("    Warning: Could not create celebrity for %s: %v", nomineeInfo.Name, err)
+					slog.Warn("could not create celebrity", "nominee", nomineeInfo.Name, "error", err)
 				} else {
 					nominee.CelebrityID = celebrity.ID
 					if celebrity.PhotoURL.Valid {
@@ -155,7 +169,7 @@ func main() {
 				// This is a film/work - try to fetch movie poster from Wikipedia
 				summary, err := wikiScraper.FetchFilmSummary(ctx, nomineeInfo.Name)
 				if err != nil {
-					log.Printf("    Warning: Could not fetch Wikipedia data for film %s: %v", nomineeInfo.Name, err)
+					slog.Warn("could not fetch Wikipedia data for film", "nominee", nomineeInfo.Name, "error", err)
 				} else if summary != nil && summary.Thumbnail != nil {
 					nominee.PhotoURL = pgtype.Text{
 						String: summary.Thumbnail.Source,
@@ -170,17 +184,18 @@ func main() {
 			// Create nominee
 			_, err := oscarService.CreateNominee(ctx, nominee)
 			if err != nil {
-				log.Printf("    Failed to create nominee %s: %v", nomineeInfo.Name, err)
+				slog.Error("failed to create nominee", "nominee", nomineeInfo.Name, "error", err)
 				continue
 			}
 			nomineesCreated++
 		}
 	}
 
-	log.Println("\n=== Setup Complete ===")
-	log.Printf("Ceremony: %s", ceremonyName)
-	log.Printf("Categories: %d", categoriesCreated)
-	log.Printf("Nominees: %d", nomineesCreated)
-	log.Printf("Celebrities created/linked: %d", celebritiesCreated)
-	log.Printf("\nView at: http://localhost:3000/oscar-race/%d", *year)
+	slog.Info("setup complete",
+		"ceremony", ceremonyName,
+		"categories", categoriesCreated,
+		"nominees", nomineesCreated,
+		"celebrities", celebritiesCreated,
+		"url", fmt.Sprintf("http://localhost:3000/oscar-race/%d", *year),
+	)
 }
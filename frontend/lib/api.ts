@@ -1,5 +1,12 @@
 const API_BASE = process.env.NEXT_PUBLIC_API_URL || "http://localhost:8080";
 
+// List endpoints wrap their results in a cursor-paginated envelope
+export interface Page<T> {
+  items: T[];
+  next_cursor: string | null;
+  total_count: number;
+}
+
 export interface Award {
   id: string;
   celebrity_id: string;
@@ -10,6 +17,18 @@ export interface Award {
   is_winner: boolean;
   ceremony_date?: string;
   is_upcoming?: boolean;
+  work_id?: string | null;
+}
+
+export interface AwardSource {
+  award_id: string;
+  source: "seed" | "wikidata" | "manual";
+  wikidata_statement_id?: string;
+  wikidata_award_id?: string;
+  wikidata_work_id?: string;
+  url?: string;
+  fetched_at?: string;
+  scraper_version?: string;
 }
 
 export interface Celebrity {
@@ -20,6 +39,27 @@ export interface Celebrity {
   summary: string | null;
   last_updated: string;
   awards: Award[];
+  sources?: AwardSource[];
+  egot_timeline?: EGOTTimeline;
+}
+
+export interface EGOTMilestone {
+  type: "Emmy" | "Grammy" | "Oscar" | "Tony";
+  letter: string;
+  year: number;
+  award_id: string;
+  work: string;
+  category: string;
+  ceremony_date?: string;
+}
+
+export interface EGOTTimeline {
+  milestones: EGOTMilestone[];
+  order: string;
+  is_complete: boolean;
+  completed_year: number | null;
+  completed_date: string | null;
+  span_years: number | null;
 }
 
 export interface EGOTStatus {
@@ -52,7 +92,7 @@ export async function searchCelebrity(name: string): Promise<Celebrity> {
 
   try {
     const response = await fetch(
-      `${API_BASE}/api/celebrity/search?q=${encodeURIComponent(name)}`,
+      `${API_BASE}/api/v1/celebrity/search?q=${encodeURIComponent(name)}`,
       { signal: controller.signal }
     );
 
@@ -86,7 +126,7 @@ export async function autocompleteCelebrity(query: string): Promise<Autocomplete
   if (!query.trim()) return [];
 
   const response = await fetch(
-    `${API_BASE}/api/celebrity/autocomplete?q=${encodeURIComponent(query)}`
+    `${API_BASE}/api/v1/celebrity/autocomplete?q=${encodeURIComponent(query)}`
   );
 
   if (!response.ok) {
@@ -109,8 +149,8 @@ export interface CelebrityWithProgress {
 
 export async function getCloseToEGOT(limit?: number): Promise<CelebrityWithProgress[]> {
   const url = limit
-    ? `${API_BASE}/api/celebrity/close-to-egot?limit=${limit}`
-    : `${API_BASE}/api/celebrity/close-to-egot`;
+    ? `${API_BASE}/api/v1/celebrity/close-to-egot?limit=${limit}`
+    : `${API_BASE}/api/v1/celebrity/close-to-egot`;
 
   const response = await fetch(url);
 
@@ -118,13 +158,14 @@ export async function getCloseToEGOT(limit?: number): Promise<CelebrityWithProgr
     throw new Error("Failed to fetch close to EGOT celebrities");
   }
 
-  return response.json();
+  const page: Page<CelebrityWithProgress> = await response.json();
+  return page.items;
 }
 
 export async function getEGOTWinners(limit?: number): Promise<CelebrityWithProgress[]> {
   const url = limit
-    ? `${API_BASE}/api/celebrity/egot-winners?limit=${limit}`
-    : `${API_BASE}/api/celebrity/egot-winners`;
+    ? `${API_BASE}/api/v1/celebrity/egot-winners?limit=${limit}`
+    : `${API_BASE}/api/v1/celebrity/egot-winners`;
 
   const response = await fetch(url);
 
@@ -132,7 +173,8 @@ export async function getEGOTWinners(limit?: number): Promise<CelebrityWithProgr
     throw new Error("Failed to fetch EGOT winners");
   }
 
-  return response.json();
+  const page: Page<CelebrityWithProgress> = await response.json();
+  return page.items;
 }
 
 export interface CelebrityBasic {
@@ -146,8 +188,8 @@ export interface CelebrityBasic {
 
 export async function getNoAwards(limit?: number): Promise<CelebrityBasic[]> {
   const url = limit
-    ? `${API_BASE}/api/celebrity/no-awards?limit=${limit}`
-    : `${API_BASE}/api/celebrity/no-awards`;
+    ? `${API_BASE}/api/v1/celebrity/no-awards?limit=${limit}`
+    : `${API_BASE}/api/v1/celebrity/no-awards`;
 
   const response = await fetch(url);
 
@@ -155,7 +197,8 @@ export async function getNoAwards(limit?: number): Promise<CelebrityBasic[]> {
     throw new Error("Failed to fetch celebrities with no awards");
   }
 
-  return response.json();
+  const page: Page<CelebrityBasic> = await response.json();
+  return page.items;
 }
 
 // Oscar Race types and functions
@@ -190,7 +233,7 @@ export interface OscarCeremony {
 }
 
 export async function getOscarCeremony(year: number): Promise<OscarCeremony> {
-  const response = await fetch(`${API_BASE}/api/oscar-race/${year}`);
+  const response = await fetch(`${API_BASE}/api/v1/oscar-race/${year}`);
 
   if (!response.ok) {
     if (response.status === 404) {
@@ -203,13 +246,14 @@ export async function getOscarCeremony(year: number): Promise<OscarCeremony> {
 }
 
 export async function getOscarYears(): Promise<number[]> {
-  const response = await fetch(`${API_BASE}/api/oscar-race/years`);
+  const response = await fetch(`${API_BASE}/api/v1/oscar-race/years`);
 
   if (!response.ok) {
     throw new Error("Failed to fetch Oscar years");
   }
 
-  return response.json();
+  const page: Page<number> = await response.json();
+  return page.items;
 }
 
 export async function setOscarWinner(
@@ -218,7 +262,7 @@ export async function setOscarWinner(
   nomineeId: string
 ): Promise<void> {
   const response = await fetch(
-    `${API_BASE}/api/oscar-race/${year}/category/${categoryId}/winner/${nomineeId}`,
+    `${API_BASE}/api/v1/oscar-race/${year}/category/${categoryId}/winner/${nomineeId}`,
     { method: "PUT" }
   );
 
@@ -226,3 +270,205 @@ export async function setOscarWinner(
     throw new Error("Failed to set winner");
   }
 }
+
+export type AwardType = "Emmy" | "Grammy" | "Oscar" | "Tony";
+
+export interface Stats {
+  celebrities: number;
+  by_award_type: {
+    type: AwardType;
+    winners: number;
+    wins: number;
+    nominations: number;
+  }[];
+  by_decade: {
+    decade: number;
+    wins: number;
+    nominations: number;
+    wins_by_type: Record<AwardType, number>;
+  }[];
+  by_progress: Record<string, number>;
+  by_sub_body: {
+    type: AwardType;
+    sub_body: string;
+    wins: number;
+    nominations: number;
+  }[];
+  generated_at: string;
+}
+
+export async function getStats(): Promise<Stats> {
+  const response = await fetch(`${API_BASE}/api/v1/stats`);
+
+  if (!response.ok) {
+    throw new Error("Failed to fetch stats");
+  }
+
+  return response.json();
+}
+
+export type LeaderboardMetric =
+  | "emmys"
+  | "grammys"
+  | "oscars"
+  | "tonys"
+  | "total_wins"
+  | "nominations"
+  | "win_span";
+
+export interface LeaderboardEntry extends CelebrityWithProgress {
+  rank: number;
+  value: number;
+}
+
+export async function getLeaderboard(
+  metric: LeaderboardMetric,
+  cursor?: string
+): Promise<Page<LeaderboardEntry>> {
+  const params = new URLSearchParams();
+  if (cursor) params.set("cursor", cursor);
+
+  const response = await fetch(`${API_BASE}/api/v1/leaderboards/${metric}?${params}`);
+
+  if (!response.ok) {
+    throw new Error("Failed to fetch leaderboard");
+  }
+
+  return response.json();
+}
+
+export interface Work {
+  id: string;
+  wikidata_id: string;
+  title: string;
+  type: "film" | "series" | "album" | "play" | "other";
+  created_at: string;
+  credits: {
+    celebrity: CelebrityBasic;
+    awards: Award[];
+  }[];
+}
+
+export async function getWork(id: string): Promise<Work> {
+  const response = await fetch(`${API_BASE}/api/v1/works/${id}`);
+
+  if (!response.ok) {
+    if (response.status === 404) {
+      throw new Error("Work not found");
+    }
+    throw new Error("Failed to fetch work");
+  }
+
+  return response.json();
+}
+
+export interface WorkSummary {
+  id: string;
+  wikidata_id: string;
+  title: string;
+  type: Work["type"];
+}
+
+export interface Collaborator extends CelebrityBasic {
+  shared_works: WorkSummary[];
+}
+
+export interface CollaborationPath {
+  from: CelebrityBasic;
+  to: CelebrityBasic;
+  degrees: number;
+  steps: { from: CelebrityBasic; work: WorkSummary; to: CelebrityBasic }[];
+}
+
+export async function getCollaborators(celebrityId: string): Promise<Collaborator[]> {
+  const response = await fetch(`${API_BASE}/api/v1/celebrity/${celebrityId}/collaborators`);
+
+  if (!response.ok) {
+    throw new Error("Failed to fetch collaborators");
+  }
+
+  return response.json();
+}
+
+export async function getCollaborationPath(from: string, to: string): Promise<CollaborationPath | null> {
+  const params = new URLSearchParams({ from, to });
+  const response = await fetch(`${API_BASE}/api/v1/path?${params}`);
+
+  if (response.status === 404) {
+    return null;
+  }
+  if (!response.ok) {
+    throw new Error("Failed to fetch path");
+  }
+
+  return response.json();
+}
+
+export interface PendingNomination {
+  award: Award;
+  celebrity: CelebrityWithProgress;
+  completes_egot: boolean;
+}
+
+export interface UpcomingCeremony {
+  type: AwardType;
+  year: number;
+  ceremony_date: string | null;
+  nominations: PendingNomination[];
+}
+
+export async function getEGOTWatch(): Promise<UpcomingCeremony[]> {
+  const response = await fetch(`${API_BASE}/api/v1/egot-watch`);
+
+  if (!response.ok) {
+    throw new Error("Failed to fetch EGOT watch");
+  }
+
+  return response.json();
+}
+
+export type EGOTEventType = "first_win" | "reached_three" | "completed_egot";
+
+export interface EGOTEvent {
+  id: string;
+  type: EGOTEventType;
+  celebrity: CelebrityBasic;
+  egot_win_count: number;
+  award: {
+    id: string;
+    type: AwardType;
+    year: number;
+    category: string;
+    work: string;
+  };
+  initial_import: boolean;
+  created_at: string;
+}
+
+export async function getEvents(
+  options: { types?: EGOTEventType[]; excludeInitial?: boolean; cursor?: string } = {}
+): Promise<Page<EGOTEvent>> {
+  const params = new URLSearchParams();
+  if (options.types?.length) params.set("type", options.types.join(","));
+  if (options.excludeInitial) params.set("exclude_initial", "true");
+  if (options.cursor) params.set("cursor", options.cursor);
+
+  const response = await fetch(`${API_BASE}/api/v1/events?${params}`);
+
+  if (!response.ok) {
+    throw new Error("Failed to fetch events");
+  }
+
+  return response.json();
+}
+
+export function celebrityFeedURL(slug: string, format: "atom" | "rss" = "atom"): string {
+  return `${API_BASE}/feeds/celebrity/${encodeURIComponent(slug)}.${format}`;
+}
+
+export function ceremonyCalendarURL(options: { closeToEGOT?: boolean } = {}): string {
+  const params = new URLSearchParams();
+  if (options.closeToEGOT) params.set("close_to_egot", "true");
+  const query = params.toString();
+  return `${API_BASE}/calendar/ceremonies.ics${query ? `?${query}` : ""}`;
+}
@@ -3,6 +3,7 @@ module egot-tracker
 go 1.22
 
 require (
+	github.com/graphql-go/graphql v0.8.1
 	github.com/jackc/pgx/v5 v5.5.5
 	github.com/joho/godotenv v1.5.1
 )
@@ -0,0 +1,172 @@
+package v1
+
+import (
+	"time"
+
+	"egot-tracker/internal/models"
+)
+
+// Award is one nomination or win
+type Award struct {
+	ID           string           `json:"id" format:"uuid"`
+	CelebrityID  string           `json:"celebrity_id" format:"uuid"`
+	Type         models.AwardType `json:"type"`
+	Year         int              `json:"year"`
+	Work         string           `json:"work"`
+	Category     string           `json:"category"`
+	IsWinner     bool             `json:"is_winner"`
+	CeremonyDate *string          `json:"ceremony_date" format:"date"`
+	IsUpcoming   bool             `json:"is_upcoming"`
+	WorkID       *string          `json:"work_id" format:"uuid"`
+}
+
+func NewAward(a models.Award) Award {
+	return Award{
+		ID:           id(a.ID),
+		CelebrityID:  id(a.CelebrityID),
+		Type:         a.Type,
+		Year:         a.Year,
+		Work:         a.Work,
+		Category:     a.Category,
+		IsWinner:     a.IsWinner,
+		CeremonyDate: optionalDate(a.CeremonyDate),
+		IsUpcoming:   a.IsUpcoming,
+		WorkID:       optionalID(a.WorkID),
+	}
+}
+
+// AwardSource describes where an award came from
+type AwardSource struct {
+	AwardID             string             `json:"award_id" format:"uuid"`
+	Source              models.AwardSource `json:"source"`
+	WikidataStatementID *string            `json:"wikidata_statement_id,omitempty"`
+	WikidataAwardID     *string            `json:"wikidata_award_id,omitempty"`
+	WikidataWorkID      *string            `json:"wikidata_work_id,omitempty"`
+	URL                 *string            `json:"url,omitempty"`
+	FetchedAt           *time.Time         `json:"fetched_at,omitempty"`
+	ScraperVersion      *string            `json:"scraper_version,omitempty"`
+}
+
+func NewAwardSource(s models.AwardSourceInfo) AwardSource {
+	return AwardSource{
+		AwardID:             id(s.AwardID),
+		Source:              s.Source,
+		WikidataStatementID: s.WikidataStatementID,
+		WikidataAwardID:     s.WikidataAwardID,
+		WikidataWorkID:      s.WikidataWorkID,
+		URL:                 s.URL,
+		FetchedAt:           s.FetchedAt,
+		ScraperVersion:      s.ScraperVersion,
+	}
+}
+
+// EGOTMilestone is the first win of one EGOT award type
+type EGOTMilestone struct {
+	Type         models.AwardType `json:"type"`
+	Letter       string           `json:"letter"`
+	Year         int              `json:"year"`
+	AwardID      string           `json:"award_id" format:"uuid"`
+	Work         string           `json:"work"`
+	Category     string           `json:"category"`
+	CeremonyDate *string          `json:"ceremony_date" format:"date"`
+}
+
+// EGOTTimeline describes when and in what order a celebrity won each award
+type EGOTTimeline struct {
+	Milestones    []EGOTMilestone `json:"milestones"`
+	Order         string          `json:"order"`
+	IsComplete    bool            `json:"is_complete"`
+	CompletedYear *int            `json:"completed_year"`
+	CompletedDate *time.Time      `json:"completed_date"`
+	SpanYears     *int            `json:"span_years"`
+}
+
+func NewEGOTTimeline(t models.EGOTTimeline) EGOTTimeline {
+	return EGOTTimeline{
+		Milestones: List(t.Milestones, func(m models.EGOTMilestone) EGOTMilestone {
+			return EGOTMilestone{
+				Type:         m.Type,
+				Letter:       m.Letter,
+				Year:         m.Year,
+				AwardID:      id(m.AwardID),
+				Work:         m.Work,
+				Category:     m.Category,
+				CeremonyDate: optionalDate(m.CeremonyDate),
+			}
+		}),
+		Order:         t.Order,
+		IsComplete:    t.IsComplete,
+		CompletedYear: t.CompletedYear,
+		CompletedDate: t.CompletedDate,
+		SpanYears:     t.SpanYears,
+	}
+}
+
+// PendingNomination is an upcoming nomination that would take the nominee
+// to 3 of 4 awards or complete their EGOT
+type PendingNomination struct {
+	Award         Award                 `json:"award"`
+	Celebrity     CelebrityWithProgress `json:"celebrity"`
+	CompletesEGOT bool                  `json:"completes_egot"`
+}
+
+// UpcomingCeremony groups the pending nominations at one ceremony
+type UpcomingCeremony struct {
+	Type         models.AwardType    `json:"type"`
+	Year         int                 `json:"year"`
+	CeremonyDate *string             `json:"ceremony_date" format:"date"`
+	Nominations  []PendingNomination `json:"nominations"`
+}
+
+func NewUpcomingCeremony(c models.UpcomingCeremony) UpcomingCeremony {
+	return UpcomingCeremony{
+		Type:         c.Type,
+		Year:         c.Year,
+		CeremonyDate: optionalDate(c.CeremonyDate),
+		Nominations: List(c.Nominations, func(n models.PendingNomination) PendingNomination {
+			return PendingNomination{
+				Award:         NewAward(n.Award),
+				Celebrity:     NewCelebrityWithProgress(n.Celebrity),
+				CompletesEGOT: n.CompletesEGOT,
+			}
+		}),
+	}
+}
+
+// EventAward is the award that triggered a milestone, as it was at the time
+type EventAward struct {
+	ID       string           `json:"id" format:"uuid"`
+	Type     models.AwardType `json:"type"`
+	Year     int              `json:"year"`
+	Category string           `json:"category"`
+	Work     string           `json:"work"`
+}
+
+// EGOTEvent records when a celebrity crossed an EGOT milestone
+type EGOTEvent struct {
+	ID            string               `json:"id" format:"uuid"`
+	Type          models.EGOTEventType `json:"type"`
+	Celebrity     Celebrity            `json:"celebrity"`
+	EGOTWinCount  int                  `json:"egot_win_count"`
+	Award         EventAward           `json:"award"`
+	InitialImport bool                 `json:"initial_import"`
+	CreatedAt     *time.Time           `json:"created_at"`
+}
+
+func NewEGOTEvent(e models.EGOTEvent) EGOTEvent {
+	return EGOTEvent{
+		ID:           id(e.ID),
+		Type:         e.Type,
+		Celebrity:    NewCelebrity(e.Celebrity),
+		EGOTWinCount: e.EGOTWinCount,
+		Award: EventAward{
+			ID:       id(e.Award.ID),
+			Type:     e.Award.Type,
+			Year:     e.Award.Year,
+			Category: e.Award.Category,
+			Work:     e.Award.Work,
+		},
+		InitialImport: e.InitialImport,
+		CreatedAt:     optionalTime(e.CreatedAt.Valid, e.CreatedAt.Time),
+	}
+}
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"time"
+
+	"egot-tracker/internal/models"
+)
+
+// Celebrity is a tracked person
+type Celebrity struct {
+	ID          string     `json:"id" format:"uuid"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	PhotoURL    *string    `json:"photo_url"`
+	Summary     *string    `json:"summary"`
+	LastUpdated *time.Time `json:"last_updated"`
+	DeathDate   *string    `json:"death_date" format:"date"`
+}
+
+func NewCelebrity(c models.Celebrity) Celebrity {
+	return Celebrity{
+		ID:          id(c.ID),
+		Name:        c.Name,
+		Slug:        c.Slug,
+		PhotoURL:    optionalText(c.PhotoURL),
+		Summary:     optionalText(c.Summary),
+		LastUpdated: optionalTime(c.LastUpdated.Valid, c.LastUpdated.Time),
+		DeathDate:   optionalDate(c.DeathDate),
+	}
+}
+
+// CelebrityWithAwards is a celebrity with every award on record, where each
+// award came from, and their EGOT timeline
+type CelebrityWithAwards struct {
+	Celebrity
+	Awards   []Award       `json:"awards"`
+	Sources  []AwardSource `json:"sources"`
+	Timeline EGOTTimeline  `json:"egot_timeline"`
+}
+
+func NewCelebrityWithAwards(c models.CelebrityWithAwards) CelebrityWithAwards {
+	return CelebrityWithAwards{
+		Celebrity: NewCelebrity(c.Celebrity),
+		Awards:    List(c.Awards, NewAward),
+		Sources:   List(c.Sources, NewAwardSource),
+		Timeline:  NewEGOTTimeline(c.Timeline),
+	}
+}
+
+// CelebrityWithProgress is a celebrity with the EGOT awards they have won
+type CelebrityWithProgress struct {
+	Celebrity
+	EGOTWinCount int      `json:"egot_win_count"`
+	WonAwards    []string `json:"won_awards"`
+}
+
+func NewCelebrityWithProgress(c models.CelebrityWithEGOTProgress) CelebrityWithProgress {
+	return CelebrityWithProgress{
+		Celebrity:    NewCelebrity(c.Celebrity),
+		EGOTWinCount: c.EGOTWinCount,
+		WonAwards:    List(c.WonAwards, func(s string) string { return s }),
+	}
+}
+
+// BrowseItem is a celebrity in browse results
+type BrowseItem struct {
+	CelebrityWithProgress
+	TotalWins     int  `json:"total_wins"`
+	LatestWinYear *int `json:"latest_win_year"`
+}
+
+func NewBrowseItem(c models.CelebrityBrowseItem) BrowseItem {
+	return BrowseItem{
+		CelebrityWithProgress: NewCelebrityWithProgress(c.CelebrityWithEGOTProgress),
+		TotalWins:             c.TotalWins,
+		LatestWinYear:         c.LatestWinYear,
+	}
+}
+
+// Facets counts the celebrities matching a browse filter along each
+// dimension
+type Facets struct {
+	WinCount map[int]int64               `json:"win_count"`
+	Missing  map[models.AwardType]int64  `json:"missing"`
+	Status   map[models.LifeStatus]int64 `json:"status"`
+}
+
+func NewFacets(f models.CelebrityFacets) Facets {
+	facets := Facets{
+		WinCount: make(map[int]int64, len(f.WinCount)),
+		Missing:  make(map[models.AwardType]int64, len(f.Missing)),
+		Status:   make(map[models.LifeStatus]int64, len(f.Status)),
+	}
+	for k, v := range f.WinCount {
+		facets.WinCount[k] = v
+	}
+	for k, v := range f.Missing {
+		facets.Missing[k] = v
+	}
+	for k, v := range f.Status {
+		facets.Status[k] = v
+	}
+	return facets
+}
+
+// Alias is an alternate name a celebrity can be found by
+type Alias struct {
+	ID          string             `json:"id" format:"uuid"`
+	CelebrityID string             `json:"celebrity_id" format:"uuid"`
+	Alias       string             `json:"alias"`
+	Source      models.AliasSource `json:"source"`
+	CreatedAt   *time.Time         `json:"created_at"`
+}
+
+func NewAlias(a models.CelebrityAlias) Alias {
+	return Alias{
+		ID:          id(a.ID),
+		CelebrityID: id(a.CelebrityID),
+		Alias:       a.Alias,
+		Source:      a.Source,
+		CreatedAt:   optionalTime(a.CreatedAt.Valid, a.CreatedAt.Time),
+	}
+}
+
+// Collaborator is a celebrity who won awards for the same works as another
+type Collaborator struct {
+	Celebrity
+	SharedWorks []Work `json:"shared_works"`
+}
+
+func NewCollaborator(c models.Collaborator) Collaborator {
+	return Collaborator{
+		Celebrity:   NewCelebrity(c.Celebrity),
+		SharedWorks: List(c.SharedWorks, NewWork),
+	}
+}
+
+// PathStep is one hop in a chain of collaborators
+type PathStep struct {
+	From Celebrity `json:"from"`
+	Work Work      `json:"work"`
+	To   Celebrity `json:"to"`
+}
+
+// CollaborationPath is the shortest chain of shared award-winning works
+// between two celebrities
+type CollaborationPath struct {
+	From    Celebrity  `json:"from"`
+	To      Celebrity  `json:"to"`
+	Degrees int        `json:"degrees"`
+	Steps   []PathStep `json:"steps"`
+}
+
+func NewCollaborationPath(p models.CollaborationPath) CollaborationPath {
+	return CollaborationPath{
+		From:    NewCelebrity(p.From),
+		To:      NewCelebrity(p.To),
+		Degrees: p.Degrees,
+		Steps: List(p.Steps, func(s models.PathStep) PathStep {
+			return PathStep{From: NewCelebrity(s.From), Work: NewWork(s.Work), To: NewCelebrity(s.To)}
+		}),
+	}
+}
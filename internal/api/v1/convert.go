@@ -0,0 +1,73 @@
+// Package v1 defines the response bodies of version 1 of the JSON API.
+//
+// These types are the API contract. They are converted from the models
+// package explicitly, so changes to models or to the database types they
+// hold do not change the JSON served to clients. Field names and shapes
+// must not change within v1; add fields rather than renaming or removing
+// them.
+package v1
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// List converts every element of a slice, returning an empty slice rather
+// than nil so lists are never encoded as null
+func List[T, U any](items []T, convert func(T) U) []U {
+	converted := make([]U, len(items))
+	for i, item := range items {
+		converted[i] = convert(item)
+	}
+	return converted
+}
+
+// id formats a UUID that is always present
+func id(u pgtype.UUID) string {
+	if !u.Valid {
+		return ""
+	}
+	value, _ := u.Value()
+	return value.(string)
+}
+
+// optionalID formats a UUID that may be null
+func optionalID(u pgtype.UUID) *string {
+	if !u.Valid {
+		return nil
+	}
+	value := id(u)
+	return &value
+}
+
+func optionalText(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
+// optionalDate formats a date as YYYY-MM-DD
+func optionalDate(d pgtype.Date) *string {
+	if !d.Valid {
+		return nil
+	}
+	value := d.Time.Format(time.DateOnly)
+	return &value
+}
+
+func optionalTime(valid bool, t time.Time) *time.Time {
+	if !valid {
+		return nil
+	}
+	return &t
+}
+
+func optionalInt(i pgtype.Int4) *int {
+	if !i.Valid {
+		return nil
+	}
+	value := int(i.Int32)
+	return &value
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+)
+
+// BrowseResult is a page of browse results with facet counts for the filter
+type BrowseResult struct {
+	pagination.Page[BrowseItem]
+	Facets Facets `json:"facets"`
+}
+
+func NewBrowseResult(page pagination.Page[models.CelebrityBrowseItem], facets *models.CelebrityFacets) BrowseResult {
+	result := BrowseResult{Page: pagination.Map(page, NewBrowseItem)}
+	if facets != nil {
+		result.Facets = NewFacets(*facets)
+	} else {
+		result.Facets = NewFacets(models.CelebrityFacets{})
+	}
+	return result
+}
+
+// AwardTypeStats counts the awards of one type and the people holding them
+type AwardTypeStats struct {
+	Type        models.AwardType `json:"type"`
+	Winners     int64            `json:"winners"`
+	Wins        int64            `json:"wins"`
+	Nominations int64            `json:"nominations"`
+}
+
+// DecadeStats counts past awards in one decade, e.g. 1990 for 1990-1999
+type DecadeStats struct {
+	Decade      int                        `json:"decade"`
+	Wins        int64                      `json:"wins"`
+	Nominations int64                      `json:"nominations"`
+	WinsByType  map[models.AwardType]int64 `json:"wins_by_type"`
+}
+
+// SubBodyStats counts past awards presented by one body within an award type
+type SubBodyStats struct {
+	Type        models.AwardType `json:"type"`
+	SubBody     string           `json:"sub_body"`
+	Wins        int64            `json:"wins"`
+	Nominations int64            `json:"nominations"`
+}
+
+// Stats summarises the tracked celebrities and their awards
+type Stats struct {
+	Celebrities int64            `json:"celebrities"`
+	ByAwardType []AwardTypeStats `json:"by_award_type"`
+	ByDecade    []DecadeStats    `json:"by_decade"`
+	ByProgress  map[int]int64    `json:"by_progress"`
+	BySubBody   []SubBodyStats   `json:"by_sub_body"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+func NewStats(s models.Stats) Stats {
+	stats := Stats{
+		Celebrities: s.Celebrities,
+		ByAwardType: List(s.ByAwardType, func(t models.AwardTypeStats) AwardTypeStats {
+			return AwardTypeStats{Type: t.Type, Winners: t.Winners, Wins: t.Wins, Nominations: t.Nominations}
+		}),
+		ByDecade: List(s.ByDecade, func(d models.DecadeStats) DecadeStats {
+			decade := DecadeStats{Decade: d.Decade, Wins: d.Wins, Nominations: d.Nominations, WinsByType: make(map[models.AwardType]int64, len(d.WinsByType))}
+			for k, v := range d.WinsByType {
+				decade.WinsByType[k] = v
+			}
+			return decade
+		}),
+		ByProgress: make(map[int]int64, len(s.ByProgress)),
+		BySubBody: List(s.BySubBody, func(b models.SubBodyStats) SubBodyStats {
+			return SubBodyStats{Type: b.Type, SubBody: b.SubBody, Wins: b.Wins, Nominations: b.Nominations}
+		}),
+		GeneratedAt: s.GeneratedAt,
+	}
+	for k, v := range s.ByProgress {
+		stats.ByProgress[k] = v
+	}
+	return stats
+}
+
+// CelebrityEGOTTimeline is an EGOT winner with their timeline
+type CelebrityEGOTTimeline struct {
+	Celebrity
+	Timeline EGOTTimeline `json:"timeline"`
+}
+
+func NewCelebrityEGOTTimeline(c models.CelebrityEGOTTimeline) CelebrityEGOTTimeline {
+	return CelebrityEGOTTimeline{Celebrity: NewCelebrity(c.Celebrity), Timeline: NewEGOTTimeline(c.Timeline)}
+}
+
+// EGOTTimelineStats lists EGOT winners by completion year and by how long
+// they took
+type EGOTTimelineStats struct {
+	ByCompletion []CelebrityEGOTTimeline `json:"by_completion"`
+	BySpan       []CelebrityEGOTTimeline `json:"by_span"`
+}
+
+func NewEGOTTimelineStats(byCompletion, bySpan []models.CelebrityEGOTTimeline) EGOTTimelineStats {
+	return EGOTTimelineStats{
+		ByCompletion: List(byCompletion, NewCelebrityEGOTTimeline),
+		BySpan:       List(bySpan, NewCelebrityEGOTTimeline),
+	}
+}
+
+// LeaderboardEntry is a celebrity's position on a leaderboard
+type LeaderboardEntry struct {
+	CelebrityWithProgress
+	Rank  int `json:"rank"`
+	Value int `json:"value"`
+}
+
+func NewLeaderboardEntry(e models.LeaderboardEntry) LeaderboardEntry {
+	return LeaderboardEntry{
+		CelebrityWithProgress: NewCelebrityWithProgress(e.CelebrityWithEGOTProgress),
+		Rank:                  e.Rank,
+		Value:                 e.Value,
+	}
+}
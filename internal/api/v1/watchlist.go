@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"encoding/json"
+	"time"
+
+	"egot-tracker/internal/models"
+)
+
+// Watchlist is a set of celebrities whose award changes are sent to a webhook
+type Watchlist struct {
+	ID         string     `json:"id" format:"uuid"`
+	Name       string     `json:"name"`
+	WebhookURL *string    `json:"webhook_url"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func NewWatchlist(w models.Watchlist) Watchlist {
+	return Watchlist{
+		ID:         id(w.ID),
+		Name:       w.Name,
+		WebhookURL: optionalText(w.WebhookURL),
+		CreatedAt:  optionalTime(w.CreatedAt.Valid, w.CreatedAt.Time),
+	}
+}
+
+// CreatedWatchlist includes the webhook secret, which is only ever returned
+// when the watchlist is created
+type CreatedWatchlist struct {
+	Watchlist
+	WebhookSecret string `json:"webhook_secret"`
+}
+
+func NewCreatedWatchlist(w models.Watchlist, secret string) CreatedWatchlist {
+	return CreatedWatchlist{Watchlist: NewWatchlist(w), WebhookSecret: secret}
+}
+
+// CalendarToken is a watchlist's calendar token, which is only ever returned
+// when it is issued
+type CalendarToken struct {
+	CalendarToken string `json:"calendar_token"`
+}
+
+// WatchlistWithCelebrities is a watchlist with the celebrities on it
+type WatchlistWithCelebrities struct {
+	Watchlist
+	Celebrities []Celebrity `json:"celebrities"`
+}
+
+func NewWatchlistWithCelebrities(w models.WatchlistWithCelebrities) WatchlistWithCelebrities {
+	return WatchlistWithCelebrities{
+		Watchlist:   NewWatchlist(w.Watchlist),
+		Celebrities: List(w.Celebrities, NewCelebrity),
+	}
+}
+
+// DeliveryAttempt is the outcome of one attempt to send a delivery
+type DeliveryAttempt struct {
+	ID          string     `json:"id" format:"uuid"`
+	DeliveryID  string     `json:"delivery_id" format:"uuid"`
+	AttemptedAt *time.Time `json:"attempted_at"`
+	StatusCode  *int       `json:"status_code"`
+	Error       *string    `json:"error"`
+	DurationMs  int        `json:"duration_ms"`
+}
+
+// Delivery is one webhook event queued for a watchlist, with its attempt log
+type Delivery struct {
+	ID            string                       `json:"id" format:"uuid"`
+	WatchlistID   string                       `json:"watchlist_id" format:"uuid"`
+	EventType     models.WebhookEventType      `json:"event_type"`
+	Payload       json.RawMessage              `json:"payload"`
+	Status        models.WebhookDeliveryStatus `json:"status"`
+	Attempts      int                          `json:"attempts"`
+	NextAttemptAt *time.Time                   `json:"next_attempt_at"`
+	CreatedAt     *time.Time                   `json:"created_at"`
+	DeliveredAt   *time.Time                   `json:"delivered_at"`
+	AttemptLog    []DeliveryAttempt            `json:"attempt_log"`
+}
+
+func NewDelivery(d models.WebhookDeliveryWithAttempts) Delivery {
+	return Delivery{
+		ID:            id(d.ID),
+		WatchlistID:   id(d.WatchlistID),
+		EventType:     d.EventType,
+		Payload:       d.Payload,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		NextAttemptAt: optionalTime(d.NextAttemptAt.Valid, d.NextAttemptAt.Time),
+		CreatedAt:     optionalTime(d.CreatedAt.Valid, d.CreatedAt.Time),
+		DeliveredAt:   optionalTime(d.DeliveredAt.Valid, d.DeliveredAt.Time),
+		AttemptLog: List(d.AttemptLog, func(a models.WebhookDeliveryAttempt) DeliveryAttempt {
+			return DeliveryAttempt{
+				ID:          id(a.ID),
+				DeliveryID:  id(a.DeliveryID),
+				AttemptedAt: optionalTime(a.AttemptedAt.Valid, a.AttemptedAt.Time),
+				StatusCode:  optionalInt(a.StatusCode),
+				Error:       optionalText(a.Error),
+				DurationMs:  a.DurationMs,
+			}
+		}),
+	}
+}
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"time"
+
+	"egot-tracker/internal/models"
+)
+
+// Work is a film, series, album or play
+type Work struct {
+	ID         string          `json:"id" format:"uuid"`
+	WikidataID string          `json:"wikidata_id"`
+	Title      string          `json:"title"`
+	Type       models.WorkType `json:"type"`
+	CreatedAt  *time.Time      `json:"created_at"`
+}
+
+func NewWork(w models.Work) Work {
+	return Work{
+		ID:         id(w.ID),
+		WikidataID: w.WikidataID,
+		Title:      w.Title,
+		Type:       w.Type,
+		CreatedAt:  optionalTime(w.CreatedAt.Valid, w.CreatedAt.Time),
+	}
+}
+
+// WorkCredit is one tracked celebrity's awards for a work
+type WorkCredit struct {
+	Celebrity Celebrity `json:"celebrity"`
+	Awards    []Award   `json:"awards"`
+}
+
+// WorkWithCredits is a work with every tracked celebrity's awards for it
+type WorkWithCredits struct {
+	Work
+	Credits []WorkCredit `json:"credits"`
+}
+
+func NewWorkWithCredits(w models.WorkWithCredits) WorkWithCredits {
+	return WorkWithCredits{
+		Work: NewWork(w.Work),
+		Credits: List(w.Credits, func(c models.WorkCredit) WorkCredit {
+			return WorkCredit{Celebrity: NewCelebrity(c.Celebrity), Awards: List(c.Awards, NewAward)}
+		}),
+	}
+}
+
+// OscarNominee is a nominee in the Oscar race
+type OscarNominee struct {
+	ID           string  `json:"id" format:"uuid"`
+	CategoryID   string  `json:"category_id" format:"uuid"`
+	CelebrityID  *string `json:"celebrity_id" format:"uuid"`
+	Name         string  `json:"name"`
+	PhotoURL     *string `json:"photo_url"`
+	WorkTitle    *string `json:"work_title"`
+	IsWinner     bool    `json:"is_winner"`
+	DisplayOrder int     `json:"display_order"`
+}
+
+// OscarCategory is a category in the Oscar race with its nominees
+type OscarCategory struct {
+	ID              string         `json:"id" format:"uuid"`
+	CeremonyID      string         `json:"ceremony_id" format:"uuid"`
+	Name            string         `json:"name"`
+	DisplayOrder    int            `json:"display_order"`
+	WinnerAnnounced bool           `json:"winner_announced"`
+	Nominees        []OscarNominee `json:"nominees"`
+}
+
+// OscarCeremony is an Oscar ceremony with every category and nominee
+type OscarCeremony struct {
+	ID           string          `json:"id" format:"uuid"`
+	Year         int             `json:"year"`
+	CeremonyName *string         `json:"ceremony_name"`
+	CeremonyDate *string         `json:"ceremony_date" format:"date"`
+	IsComplete   bool            `json:"is_complete"`
+	CreatedAt    *time.Time      `json:"created_at"`
+	Categories   []OscarCategory `json:"categories"`
+}
+
+func NewOscarCeremony(c models.OscarCeremonyFull) OscarCeremony {
+	return OscarCeremony{
+		ID:           id(c.ID),
+		Year:         c.Year,
+		CeremonyName: optionalText(c.CeremonyName),
+		CeremonyDate: optionalDate(c.CeremonyDate),
+		IsComplete:   c.IsComplete,
+		CreatedAt:    optionalTime(c.CreatedAt.Valid, c.CreatedAt.Time),
+		Categories: List(c.Categories, func(category models.OscarCategoryWithNominees) OscarCategory {
+			return OscarCategory{
+				ID:              id(category.ID),
+				CeremonyID:      id(category.CeremonyID),
+				Name:            category.Name,
+				DisplayOrder:    category.DisplayOrder,
+				WinnerAnnounced: category.WinnerAnnounced,
+				Nominees: List(category.Nominees, func(n models.OscarNominee) OscarNominee {
+					return OscarNominee{
+						ID:           id(n.ID),
+						CategoryID:   id(n.CategoryID),
+						CelebrityID:  optionalID(n.CelebrityID),
+						Name:         n.Name,
+						PhotoURL:     optionalText(n.PhotoURL),
+						WorkTitle:    optionalText(n.WorkTitle),
+						IsWinner:     n.IsWinner,
+						DisplayOrder: n.DisplayOrder,
+					}
+				}),
+			}
+		}),
+	}
+}
@@ -2,12 +2,26 @@ package config
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
 )
 
 type Config struct {
 	DatabaseURL string
 	Port        string
+	// CheckSchema makes the API refuse to start when migrations are pending
+	CheckSchema bool
+	// SiteURL is the public URL of the frontend, used for links in feeds
+	SiteURL string
+	// Development validates requests and responses against the OpenAPI
+	// document
+	Development bool
+	// LogLevel is the least severe level logged
+	LogLevel slog.Level
+	// RefreshEnabled runs the background celebrity refresher
+	RefreshEnabled bool
 }
 
 func Load() (*Config, error) {
@@ -21,8 +35,50 @@ func Load() (*Config, error) {
 		port = "8080"
 	}
 
+	checkSchema := true
+	if value := os.Getenv("CHECK_SCHEMA"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CHECK_SCHEMA value %q: %w", value, err)
+		}
+		checkSchema = parsed
+	}
+
+	siteURL := strings.TrimRight(os.Getenv("SITE_URL"), "/")
+	if siteURL == "" {
+		siteURL = "http://localhost:3210"
+	}
+
+	env := os.Getenv("APP_ENV")
+	switch env {
+	case "", "production", "development":
+	default:
+		return nil, fmt.Errorf("invalid APP_ENV value %q: must be development or production", env)
+	}
+
+	logLevel := slog.LevelInfo
+	if value := os.Getenv("LOG_LEVEL"); value != "" {
+		if err := logLevel.UnmarshalText([]byte(value)); err != nil {
+			return nil, fmt.Errorf("invalid LOG_LEVEL value %q: must be debug, info, warn or error", value)
+		}
+	}
+
+	refreshEnabled := true
+	if value := os.Getenv("REFRESH_ENABLED"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid REFRESH_ENABLED value %q: %w", value, err)
+		}
+		refreshEnabled = parsed
+	}
+
 	return &Config{
-		DatabaseURL: dbURL,
-		Port:        port,
+		DatabaseURL:    dbURL,
+		Port:           port,
+		CheckSchema:    checkSchema,
+		SiteURL:        siteURL,
+		Development:    env == "development",
+		LogLevel:       logLevel,
+		RefreshEnabled: refreshEnabled,
 	}, nil
 }
@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AwardsChangedChannel is notified by triggers whenever awards or works change
+const AwardsChangedChannel = "awards_changed"
+
+// listenRetryDelay is how long Listen waits before reconnecting after an error
+const listenRetryDelay = 5 * time.Second
+
+// Listen calls fn whenever a notification arrives on channel, until ctx is
+// cancelled. It holds its own connection rather than one from the pool, and
+// reconnects after errors. fn is also called after every (re)connect, since
+// notifications sent while disconnected are lost.
+func Listen(ctx context.Context, pool *pgxpool.Pool, channel string, fn func()) {
+	for {
+		err := listenOnce(ctx, pool, channel, fn)
+		if ctx.Err() != nil {
+			return
+		}
+		slog.Warn("listener failed, retrying", "channel", channel, "retry_in", listenRetryDelay.String(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func listenOnce(ctx context.Context, pool *pgxpool.Pool, channel string, fn func()) error {
+	conn, err := pgx.ConnectConfig(ctx, pool.Config().ConnConfig.Copy())
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return err
+	}
+	fn()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		fn()
+	}
+}
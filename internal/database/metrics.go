@@ -0,0 +1,34 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"egot-tracker/internal/metrics"
+)
+
+// RegisterPoolMetrics exposes the connection pool's statistics, read from
+// the pool at scrape time
+func RegisterPoolMetrics(pool *pgxpool.Pool) {
+	gauge := func(name, help string, fn func(*pgxpool.Stat) int32) {
+		metrics.NewGaugeFunc(name, help, func() float64 { return float64(fn(pool.Stat())) })
+	}
+	counter := func(name, help string, fn func(*pgxpool.Stat) int64) {
+		metrics.NewCounterFunc(name, help, func() float64 { return float64(fn(pool.Stat())) })
+	}
+
+	gauge("egot_db_pool_acquired_conns", "Connections currently in use.", (*pgxpool.Stat).AcquiredConns)
+	gauge("egot_db_pool_idle_conns", "Connections currently idle.", (*pgxpool.Stat).IdleConns)
+	gauge("egot_db_pool_constructing_conns", "Connections currently being established.", (*pgxpool.Stat).ConstructingConns)
+	gauge("egot_db_pool_total_conns", "Connections in the pool, in any state.", (*pgxpool.Stat).TotalConns)
+	gauge("egot_db_pool_max_conns", "Maximum size of the pool.", (*pgxpool.Stat).MaxConns)
+
+	counter("egot_db_pool_acquires_total", "Connections acquired from the pool.", (*pgxpool.Stat).AcquireCount)
+	counter("egot_db_pool_empty_acquires_total", "Acquires that had to wait because no idle connection was available.", (*pgxpool.Stat).EmptyAcquireCount)
+	counter("egot_db_pool_canceled_acquires_total", "Acquires cancelled by their context before a connection was available.", (*pgxpool.Stat).CanceledAcquireCount)
+	counter("egot_db_pool_new_conns_total", "Connections opened.", (*pgxpool.Stat).NewConnsCount)
+	counter("egot_db_pool_max_lifetime_destroys_total", "Connections closed for exceeding their maximum lifetime.", (*pgxpool.Stat).MaxLifetimeDestroyCount)
+	counter("egot_db_pool_max_idle_destroys_total", "Connections closed for exceeding their maximum idle time.", (*pgxpool.Stat).MaxIdleDestroyCount)
+	metrics.NewCounterFunc("egot_db_pool_acquire_duration_seconds_total", "Total time spent acquiring connections.", func() float64 {
+		return pool.Stat().AcquireDuration().Seconds()
+	})
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS oscar_nominees;
+DROP TABLE IF EXISTS oscar_categories;
+DROP TABLE IF EXISTS oscar_ceremonies;
+DROP TABLE IF EXISTS awards;
+DROP TABLE IF EXISTS celebrities;
+DROP TYPE IF EXISTS award_type;
@@ -0,0 +1,69 @@
+-- Initial schema: celebrities, their awards, and the Oscar race tables.
+-- Every statement is idempotent so databases created from the old
+-- setup.sql can adopt versioned migrations without changes.
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'award_type') THEN
+        CREATE TYPE award_type AS ENUM ('Emmy', 'Grammy', 'Oscar', 'Tony');
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS celebrities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name TEXT NOT NULL UNIQUE,
+    slug TEXT NOT NULL,
+    photo_url TEXT,
+    last_updated TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_celebrities_name ON celebrities (LOWER(name));
+
+CREATE TABLE IF NOT EXISTS awards (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    celebrity_id UUID NOT NULL REFERENCES celebrities(id) ON DELETE CASCADE,
+    type award_type NOT NULL,
+    year INTEGER NOT NULL,
+    work TEXT NOT NULL,
+    category TEXT NOT NULL,
+    is_winner BOOLEAN NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS idx_awards_celebrity_id ON awards (celebrity_id);
+CREATE INDEX IF NOT EXISTS idx_awards_type ON awards (type);
+
+-- Oscar ceremony tracking
+CREATE TABLE IF NOT EXISTS oscar_ceremonies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    year INTEGER UNIQUE NOT NULL,
+    ceremony_name TEXT,
+    ceremony_date DATE,
+    is_complete BOOLEAN DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Oscar categories for each year
+CREATE TABLE IF NOT EXISTS oscar_categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ceremony_id UUID REFERENCES oscar_ceremonies(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    display_order INTEGER DEFAULT 0,
+    winner_announced BOOLEAN DEFAULT false
+);
+
+-- Oscar nominees (can be person or work)
+CREATE TABLE IF NOT EXISTS oscar_nominees (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    category_id UUID REFERENCES oscar_categories(id) ON DELETE CASCADE,
+    celebrity_id UUID REFERENCES celebrities(id) ON DELETE SET NULL,
+    name TEXT NOT NULL,
+    photo_url TEXT,
+    work_title TEXT,
+    is_winner BOOLEAN DEFAULT false,
+    display_order INTEGER DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_oscar_categories_ceremony ON oscar_categories(ceremony_id);
+CREATE INDEX IF NOT EXISTS idx_oscar_nominees_category ON oscar_nominees(category_id);
+CREATE INDEX IF NOT EXISTS idx_oscar_nominees_celebrity ON oscar_nominees(celebrity_id);
@@ -0,0 +1,3 @@
+ALTER TABLE awards DROP COLUMN IF EXISTS is_upcoming;
+ALTER TABLE awards DROP COLUMN IF EXISTS ceremony_date;
+ALTER TABLE celebrities DROP COLUMN IF EXISTS summary;
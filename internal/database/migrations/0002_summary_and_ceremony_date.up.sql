@@ -0,0 +1,6 @@
+-- Wikipedia summary for each celebrity
+ALTER TABLE celebrities ADD COLUMN IF NOT EXISTS summary TEXT;
+
+-- Ceremony date and upcoming flag for nominations at future ceremonies
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS ceremony_date DATE;
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS is_upcoming BOOLEAN NOT NULL DEFAULT false;
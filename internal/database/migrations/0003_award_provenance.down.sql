@@ -0,0 +1,5 @@
+ALTER TABLE awards DROP COLUMN IF EXISTS scraper_version;
+ALTER TABLE awards DROP COLUMN IF EXISTS fetched_at;
+ALTER TABLE awards DROP COLUMN IF EXISTS wikidata_award_id;
+ALTER TABLE awards DROP COLUMN IF EXISTS wikidata_statement_id;
+ALTER TABLE awards DROP COLUMN IF EXISTS source;
@@ -0,0 +1,11 @@
+-- Source system the row came from; existing rows are treated as seed data
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT 'seed'
+    CHECK (source IN ('seed', 'wikidata', 'manual'));
+
+-- Wikidata statement ID (e.g. Q42$F078E5B3-...) and award item QID
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS wikidata_statement_id TEXT;
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS wikidata_award_id TEXT;
+
+-- When the row was fetched and by which scraper version
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS fetched_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS scraper_version TEXT;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_awards_natural_key;
@@ -0,0 +1,12 @@
+-- Remove duplicates left by repeated imports, keeping one row per natural key
+-- (same ranking as cmd/dedupe-awards), then enforce the key
+WITH ranked AS (
+    SELECT id, ROW_NUMBER() OVER (
+        PARTITION BY celebrity_id, type, category, year, work
+        ORDER BY is_winner DESC, (wikidata_statement_id IS NULL), fetched_at DESC NULLS LAST, id
+    ) AS rn
+    FROM awards
+)
+DELETE FROM awards WHERE id IN (SELECT id FROM ranked WHERE rn > 1);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_awards_natural_key ON awards (celebrity_id, type, category, year, work);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_celebrities_name_trgm;
+DROP FUNCTION IF EXISTS immutable_unaccent(text);
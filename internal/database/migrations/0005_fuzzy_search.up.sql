@@ -0,0 +1,11 @@
+-- Extensions for fuzzy, accent-insensitive name search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE, so wrap it to allow use in indexes
+CREATE OR REPLACE FUNCTION immutable_unaccent(text) RETURNS text AS $$
+    SELECT public.unaccent('public.unaccent', $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Trigram index for fuzzy, accent-insensitive search
+CREATE INDEX IF NOT EXISTS idx_celebrities_name_trgm ON celebrities USING gin (immutable_unaccent(LOWER(name)) gin_trgm_ops);
@@ -0,0 +1,14 @@
+-- Alternate names (birth names, stage names) a celebrity can be found by
+CREATE TABLE IF NOT EXISTS celebrity_aliases (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    celebrity_id UUID NOT NULL REFERENCES celebrities(id) ON DELETE CASCADE,
+    alias TEXT NOT NULL,
+    source TEXT NOT NULL DEFAULT 'manual' CHECK (source IN ('wikidata', 'manual')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- One copy of each alias per celebrity, ignoring case and accents
+CREATE UNIQUE INDEX IF NOT EXISTS idx_celebrity_aliases_unique ON celebrity_aliases (celebrity_id, immutable_unaccent(LOWER(alias)));
+
+-- Trigram index for fuzzy alias search
+CREATE INDEX IF NOT EXISTS idx_celebrity_aliases_trgm ON celebrity_aliases USING gin (immutable_unaccent(LOWER(alias)) gin_trgm_ops);
@@ -0,0 +1 @@
+ALTER TABLE celebrities DROP COLUMN IF EXISTS death_date;
@@ -0,0 +1,2 @@
+-- Date of death from Wikidata (P570); NULL for living or unknown
+ALTER TABLE celebrities ADD COLUMN IF NOT EXISTS death_date DATE;
@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS award_sub_body(award_type, TEXT);
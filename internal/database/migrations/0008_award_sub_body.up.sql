@@ -0,0 +1,31 @@
+-- Classify an award into the body that presents it, from its category text.
+-- Categories come from Wikidata labels such as "Daytime Emmy Award for ...".
+CREATE OR REPLACE FUNCTION award_sub_body(award_type award_type, category TEXT)
+RETURNS TEXT AS $$
+    SELECT CASE award_type
+        WHEN 'Emmy' THEN CASE
+            WHEN category ILIKE '%Daytime Emmy%' THEN 'Daytime Emmy'
+            WHEN category ILIKE '%International Emmy%' THEN 'International Emmy'
+            WHEN category ILIKE '%News%Emmy%' OR category ILIKE '%Documentary Emmy%' THEN 'News & Documentary Emmy'
+            WHEN category ILIKE '%Sports Emmy%' THEN 'Sports Emmy'
+            WHEN category ILIKE '%Children%Emmy%' THEN 'Children''s & Family Emmy'
+            ELSE 'Primetime Emmy'
+        END
+        WHEN 'Grammy' THEN CASE
+            WHEN category ILIKE '%Latin Grammy%' THEN 'Latin Grammy'
+            WHEN category ILIKE '%Lifetime Achievement%' OR category ILIKE '%Trustees%'
+              OR category ILIKE '%Legend%' OR category ILIKE '%Hall of Fame%' THEN 'Grammy Special Merit'
+            ELSE 'Grammy'
+        END
+        WHEN 'Oscar' THEN CASE
+            WHEN category ILIKE '%Honorary%' OR category ILIKE '%Jean Hersholt%'
+              OR category ILIKE '%Thalberg%' OR category ILIKE '%Special Achievement%' THEN 'Academy Honorary'
+            ELSE 'Academy Award'
+        END
+        WHEN 'Tony' THEN CASE
+            WHEN category ILIKE '%Special Tony%' OR category ILIKE '%Regional Theatre%'
+              OR category ILIKE '%Isabelle Stevenson%' OR category ILIKE '%Lifetime Achievement%' THEN 'Special Tony'
+            ELSE 'Tony'
+        END
+    END
+$$ LANGUAGE sql IMMUTABLE;
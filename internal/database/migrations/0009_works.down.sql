@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_awards_work_id;
+ALTER TABLE awards DROP COLUMN IF EXISTS work_id;
+ALTER TABLE awards DROP COLUMN IF EXISTS wikidata_work_id;
+DROP TABLE IF EXISTS works;
@@ -0,0 +1,16 @@
+-- Films, series, albums and plays that awards are given for, keyed by the
+-- Wikidata QID of the work named on each award statement
+CREATE TABLE IF NOT EXISTS works (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    wikidata_id TEXT NOT NULL UNIQUE,
+    title TEXT NOT NULL,
+    type TEXT NOT NULL DEFAULT 'other'
+        CHECK (type IN ('film', 'series', 'album', 'play', 'other')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Work QID as read from Wikidata, and the work it resolved to
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS wikidata_work_id TEXT;
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS work_id UUID REFERENCES works(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_awards_work_id ON awards (work_id);
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS works_changed ON works;
+DROP TRIGGER IF EXISTS awards_changed ON awards;
+DROP FUNCTION IF EXISTS notify_awards_changed();
@@ -0,0 +1,19 @@
+-- Notify listeners on the awards_changed channel whenever awards or works
+-- change, so in-memory views built from them can be refreshed. Notifications
+-- are delivered on commit and collapsed within a transaction.
+CREATE OR REPLACE FUNCTION notify_awards_changed() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('awards_changed', TG_TABLE_NAME);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS awards_changed ON awards;
+CREATE TRIGGER awards_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON awards
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_awards_changed();
+
+DROP TRIGGER IF EXISTS works_changed ON works;
+CREATE TRIGGER works_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON works
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_awards_changed();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS egot_events;
+DROP FUNCTION IF EXISTS reject_egot_event_update();
@@ -0,0 +1,71 @@
+-- Milestones in a celebrity's EGOT progress, recorded when awards are
+-- written. The triggering award is copied into the event rather than
+-- referenced, so events survive award cleanup and are never rewritten.
+CREATE TABLE IF NOT EXISTS egot_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    celebrity_id UUID NOT NULL REFERENCES celebrities(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL
+        CHECK (event_type IN ('first_win', 'reached_three', 'completed_egot')),
+    egot_win_count INTEGER NOT NULL,
+    award_id UUID NOT NULL,
+    award_type award_type NOT NULL,
+    award_year INTEGER NOT NULL,
+    award_category TEXT NOT NULL,
+    award_work TEXT NOT NULL,
+    -- True when detected on the celebrity's first import, i.e. the milestone
+    -- happened before we tracked them
+    initial_import BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Each milestone happens once per celebrity (first_win once per award type)
+CREATE UNIQUE INDEX IF NOT EXISTS idx_egot_events_first_win
+    ON egot_events (celebrity_id, award_type) WHERE event_type = 'first_win';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_egot_events_threshold
+    ON egot_events (celebrity_id, event_type) WHERE event_type <> 'first_win';
+
+CREATE INDEX IF NOT EXISTS idx_egot_events_created_at ON egot_events (created_at DESC, id);
+
+-- Backfill milestones already reached by celebrities tracked before events
+-- were recorded. This is a frozen snapshot of the ranking as it was when the
+-- migration was written; EventRepository.Backfill owns the live query, and
+-- changes to milestone ranking go there, not here.
+WITH first_wins AS (
+    SELECT DISTINCT ON (celebrity_id, type)
+        celebrity_id, id, type, year, category, work, ceremony_date
+    FROM awards
+    WHERE is_winner = true AND is_upcoming = false
+    ORDER BY celebrity_id, type, (year = 0), year, ceremony_date NULLS LAST, id
+),
+ranked AS (
+    SELECT *, ROW_NUMBER() OVER (
+        PARTITION BY celebrity_id
+        ORDER BY (year = 0), year, ceremony_date NULLS LAST, type
+    ) AS win_count
+    FROM first_wins
+)
+INSERT INTO egot_events (
+    celebrity_id, event_type, egot_win_count,
+    award_id, award_type, award_year, award_category, award_work, initial_import
+)
+SELECT r.celebrity_id, t.event_type, r.win_count,
+    r.id, r.type, r.year, r.category, r.work, true
+FROM ranked r
+CROSS JOIN LATERAL (
+    SELECT 'first_win' AS event_type
+    UNION ALL SELECT 'reached_three' WHERE r.win_count = 3
+    UNION ALL SELECT 'completed_egot' WHERE r.win_count = 4
+) t
+ON CONFLICT DO NOTHING;
+
+-- Events are immutable
+CREATE OR REPLACE FUNCTION reject_egot_event_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'egot_events are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS egot_events_immutable ON egot_events;
+CREATE TRIGGER egot_events_immutable
+    BEFORE UPDATE ON egot_events
+    FOR EACH ROW EXECUTE FUNCTION reject_egot_event_update();
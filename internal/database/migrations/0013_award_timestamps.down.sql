@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_awards_updated_at;
+ALTER TABLE awards DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE awards DROP COLUMN IF EXISTS created_at;
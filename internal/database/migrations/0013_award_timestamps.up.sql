@@ -0,0 +1,21 @@
+-- When each award row was first added and when its outcome last changed,
+-- for feeds of recent awards
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS created_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE awards ADD COLUMN IF NOT EXISTS updated_at TIMESTAMP WITH TIME ZONE;
+
+-- Existing awards were added no later than they were fetched or their
+-- celebrity was last updated
+UPDATE awards a
+SET created_at = COALESCE(a.fetched_at, c.last_updated, NOW())
+FROM celebrities c
+WHERE c.id = a.celebrity_id AND a.created_at IS NULL;
+
+UPDATE awards SET updated_at = created_at WHERE updated_at IS NULL;
+
+ALTER TABLE awards
+    ALTER COLUMN created_at SET DEFAULT NOW(),
+    ALTER COLUMN created_at SET NOT NULL,
+    ALTER COLUMN updated_at SET DEFAULT NOW(),
+    ALTER COLUMN updated_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_awards_updated_at ON awards (updated_at DESC, id);
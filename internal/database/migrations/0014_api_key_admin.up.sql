@@ -0,0 +1,2 @@
+-- Admin API keys may also curate data, such as adding celebrity aliases
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT false;
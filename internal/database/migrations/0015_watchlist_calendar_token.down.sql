@@ -0,0 +1 @@
+ALTER TABLE watchlists DROP COLUMN IF EXISTS calendar_token_hash;
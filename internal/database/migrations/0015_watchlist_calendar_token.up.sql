@@ -0,0 +1,3 @@
+-- Read-only token for a watchlist's calendar feed, which calendar apps send
+-- in the URL. Only its hash is stored.
+ALTER TABLE watchlists ADD COLUMN IF NOT EXISTS calendar_token_hash TEXT UNIQUE;
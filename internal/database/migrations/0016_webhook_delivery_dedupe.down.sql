@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_dedupe;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS dedupe_key;
@@ -0,0 +1,7 @@
+-- Identifies the change a delivery reports, so that a change seen by two
+-- concurrent writers, such as refreshes on two API replicas, is queued for
+-- each watchlist once
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS dedupe_key TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_dedupe
+    ON webhook_deliveries (watchlist_id, dedupe_key);
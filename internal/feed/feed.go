@@ -0,0 +1,181 @@
+// Package feed renders Atom and RSS feeds
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// Feed is a feed independent of its format
+type Feed struct {
+	ID       string // permanent, globally unique identifier
+	Title    string
+	Subtitle string
+	Link     string // the web page the feed describes
+	SelfLink string // the URL of the feed itself
+	Updated  time.Time
+	Entries  []Entry
+}
+
+// Entry is a single feed item
+type Entry struct {
+	ID        string // permanent, globally unique identifier
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
+
+// LatestUpdate returns the latest of updated and every entry's update time
+func LatestUpdate(updated time.Time, entries []Entry) time.Time {
+	for _, e := range entries {
+		if e.Updated.After(updated) {
+			updated = e.Updated
+		}
+	}
+	return updated
+}
+
+// updated returns when the feed was last updated, or now for an empty feed
+func (f Feed) updated() time.Time {
+	if f.Updated.IsZero() {
+		return time.Now().UTC()
+	}
+	return f.Updated.UTC()
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Links    []atomLink  `xml:"link"`
+	Updated  string      `xml:"updated"`
+	Author   atomAuthor  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Summary   string   `xml:"summary,omitempty"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+}
+
+// WriteAtom writes f as an Atom 1.0 document
+func WriteAtom(w io.Writer, f Feed) error {
+	doc := atomFeed{
+		ID:       f.ID,
+		Title:    f.Title,
+		Subtitle: f.Subtitle,
+		Links: []atomLink{
+			{Rel: "alternate", Href: f.Link},
+			{Rel: "self", Href: f.SelfLink},
+		},
+		Updated: f.updated().Format(time.RFC3339),
+		Author:  atomAuthor{Name: "EGOT Tracker"},
+	}
+	for _, e := range f.Entries {
+		doc.Entries = append(doc.Entries, atomEntry{
+			ID:        e.ID,
+			Title:     e.Title,
+			Link:      atomLink{Rel: "alternate", Href: e.Link},
+			Summary:   e.Summary,
+			Published: e.Published.UTC().Format(time.RFC3339),
+			Updated:   e.Updated.UTC().Format(time.RFC3339),
+		})
+	}
+	return write(w, doc)
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Atom    string     `xml:"xmlns:atom,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      rssSelf   `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssSelf struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	GUID        rssGUID `xml:"guid"`
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// WriteRSS writes f as an RSS 2.0 document. RSS has no per-item update
+// time, so items are dated by when they were last updated.
+func WriteRSS(w io.Writer, f Feed) error {
+	description := f.Subtitle
+	if description == "" {
+		description = f.Title
+	}
+
+	doc := rssDocument{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   description,
+			SelfLink:      rssSelf{Href: f.SelfLink, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: f.updated().Format(time.RFC1123Z),
+		},
+	}
+	for _, e := range f.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			GUID:        rssGUID{Value: e.ID},
+			Title:       e.Title,
+			Link:        e.Link,
+			Description: e.Summary,
+			PubDate:     e.Updated.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return write(w, doc)
+}
+
+func write(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
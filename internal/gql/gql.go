@@ -0,0 +1,59 @@
+// Package gql serves the read-only GraphQL API over the repositories.
+package gql
+
+import (
+	"context"
+
+	"egot-tracker/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+)
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	OperationName string                 `json:"operationName,omitempty"`
+}
+
+// Executor runs GraphQL requests against the schema
+type Executor struct {
+	schema graphql.Schema
+	repos  *Repositories
+}
+
+func NewExecutor(repos *Repositories, celebrities *service.CelebrityService) (*Executor, error) {
+	schema, err := newSchema(repos, celebrities)
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{schema: schema, repos: repos}, nil
+}
+
+// Execute parses, validates and cost-checks a request, then resolves it
+// with a fresh set of loaders
+func (e *Executor) Execute(ctx context.Context, req Request) *graphql.Result {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&e.schema, doc, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	if err := checkLimits(&e.schema, doc, req.OperationName, req.Variables); err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        e.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       withLoaders(ctx, newLoaders(e.repos)),
+	})
+}
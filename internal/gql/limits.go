@@ -0,0 +1,164 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// MaxDepth is the deepest field nesting a query may select
+	MaxDepth = 10
+	// MaxComplexity is the highest estimated number of fields a query may
+	// resolve
+	MaxComplexity = 5000
+	// defaultListSize is the assumed length of list fields without a page
+	// size argument
+	defaultListSize = 10
+)
+
+// cost is the measured size of a selection set
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// measurer estimates query cost statically, before anything is resolved.
+// Each field costs 1, and the cost of a list's selections is multiplied by
+// its page size, or by defaultListSize for unpaginated lists. Introspection
+// fields are not counted.
+type measurer struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// checkLimits rejects operations deeper than MaxDepth or more complex than
+// MaxComplexity. The document must already be valid.
+func checkLimits(schema *graphql.Schema, doc *ast.Document, operationName string, variables map[string]interface{}) error {
+	m := &measurer{
+		schema:    schema,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch d := definition.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[d.Name.Value] = d
+		case *ast.OperationDefinition:
+			if operationName == "" || (d.Name != nil && d.Name.Value == operationName) {
+				operations = append(operations, d)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		if operation.Operation != ast.OperationTypeQuery {
+			continue
+		}
+		c := m.measure(operation.SelectionSet, schema.QueryType(), 1, false)
+		if c.depth > MaxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", c.depth, MaxDepth)
+		}
+		if c.complexity > MaxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", c.complexity, MaxComplexity)
+		}
+	}
+	return nil
+}
+
+// measure returns the cost of a selection set on parent, whose fields are at
+// the given depth. sized is true when the enclosing field already applied a
+// page size, so lists directly inside it are not multiplied again.
+func (m *measurer) measure(set *ast.SelectionSet, parent *graphql.Object, depth int, sized bool) cost {
+	var total cost
+	if set == nil || parent == nil {
+		return total
+	}
+
+	add := func(c cost) {
+		total.depth = max(total.depth, c.depth)
+		total.complexity += c.complexity
+	}
+
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name.Value, "__") {
+				continue
+			}
+			def, ok := parent.Fields()[s.Name.Value]
+			if !ok {
+				continue
+			}
+
+			field := cost{depth: depth, complexity: 1}
+			if s.SelectionSet != nil {
+				child, _ := graphql.GetNamed(def.Type).(*graphql.Object)
+				size, paged := m.listSize(s, def, sized)
+				nested := m.measure(s.SelectionSet, child, depth+1, paged)
+				field.depth = max(field.depth, nested.depth)
+				field.complexity += nested.complexity * size
+			}
+			add(field)
+		case *ast.InlineFragment:
+			target := parent
+			if s.TypeCondition != nil {
+				target, _ = m.schema.Type(s.TypeCondition.Name.Value).(*graphql.Object)
+			}
+			add(m.measure(s.SelectionSet, target, depth, sized))
+		case *ast.FragmentSpread:
+			fragment, ok := m.fragments[s.Name.Value]
+			if !ok {
+				continue
+			}
+			target, _ := m.schema.Type(fragment.TypeCondition.Name.Value).(*graphql.Object)
+			add(m.measure(fragment.SelectionSet, target, depth, sized))
+		}
+	}
+	return total
+}
+
+// listSize returns how many times a field's selections may be resolved, and
+// whether that came from a page size argument
+func (m *measurer) listSize(field *ast.Field, def *graphql.FieldDefinition, sized bool) (int, bool) {
+	for _, arg := range def.Args {
+		if arg.Name() != "first" {
+			continue
+		}
+		size, _ := arg.DefaultValue.(int)
+		for _, given := range field.Arguments {
+			if given.Name.Value == "first" {
+				size = m.intValue(given.Value, size)
+			}
+		}
+		return max(size, 1), true
+	}
+
+	if _, isList := graphql.GetNullable(def.Type).(*graphql.List); isList && !sized {
+		return defaultListSize, false
+	}
+	return 1, false
+}
+
+// intValue reads an integer literal or variable, falling back to def
+func (m *measurer) intValue(value ast.Value, def int) int {
+	switch v := value.(type) {
+	case *ast.IntValue:
+		if parsed, err := strconv.Atoi(v.Value); err == nil {
+			return parsed
+		}
+	case *ast.Variable:
+		switch n := m.variables[v.Name.Value].(type) {
+		case int:
+			return n
+		case float64:
+			return int(n)
+		}
+	}
+	return def
+}
@@ -0,0 +1,154 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// loader batches lookups by ID. Resolvers queue an ID and return a thunk;
+// graphql-go runs thunks breadth first, so every ID queued while resolving
+// one level of a query is fetched with a single repository call.
+type loader[V any] struct {
+	mu      sync.Mutex
+	fetch   func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte]V, error)
+	queued  []pgtype.UUID
+	seen    map[[16]byte]bool
+	results map[[16]byte]V
+	errs    map[[16]byte]error
+}
+
+func newLoader[V any](fetch func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte]V, error)) *loader[V] {
+	return &loader[V]{
+		fetch:   fetch,
+		seen:    make(map[[16]byte]bool),
+		results: make(map[[16]byte]V),
+		errs:    make(map[[16]byte]error),
+	}
+}
+
+// load queues an ID and returns a thunk yielding its value, or the zero
+// value if there is none
+func (l *loader[V]) load(ctx context.Context, id pgtype.UUID) func() (V, error) {
+	l.mu.Lock()
+	if !l.seen[id.Bytes] {
+		l.seen[id.Bytes] = true
+		l.queued = append(l.queued, id)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.queued) > 0 {
+			batch := l.queued
+			l.queued = nil
+			found, err := l.fetch(ctx, batch)
+			for _, queued := range batch {
+				if err != nil {
+					l.errs[queued.Bytes] = err
+					continue
+				}
+				l.results[queued.Bytes] = found[queued.Bytes]
+			}
+		}
+		return l.results[id.Bytes], l.errs[id.Bytes]
+	}
+}
+
+// thunk adapts a loader thunk to the signature graphql-go resolves lazily
+func thunk[V any](load func() (V, error), convert func(V) any) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := load()
+		if err != nil {
+			return nil, err
+		}
+		return convert(value), nil
+	}
+}
+
+// loaders holds the loaders for one request, so results are never shared
+// between requests
+type loaders struct {
+	celebrity          *loader[*models.Celebrity]
+	awardsByCelebrity  *loader[[]models.Award]
+	awardsByWork       *loader[[]models.Award]
+	aliasesByCelebrity *loader[[]models.CelebrityAlias]
+	work               *loader[*models.Work]
+	categories         *loader[[]models.OscarCategory]
+	nominees           *loader[[]models.OscarNominee]
+}
+
+func newLoaders(r *Repositories) *loaders {
+	return &loaders{
+		celebrity: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte]*models.Celebrity, error) {
+			celebrities, err := r.Celebrities.FindByIDs(ctx, ids)
+			return byID(celebrities, func(c models.Celebrity) pgtype.UUID { return c.ID }), err
+		}),
+		awardsByCelebrity: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte][]models.Award, error) {
+			awards, err := r.Awards.FindByCelebrityIDs(ctx, ids)
+			return groupBy(awards, func(a models.Award) pgtype.UUID { return a.CelebrityID }), err
+		}),
+		awardsByWork: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte][]models.Award, error) {
+			awards, err := r.Awards.FindByWorkIDs(ctx, ids)
+			return groupBy(awards, func(a models.Award) pgtype.UUID { return a.WorkID }), err
+		}),
+		aliasesByCelebrity: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte][]models.CelebrityAlias, error) {
+			aliases, err := r.Aliases.FindByCelebrityIDs(ctx, ids)
+			return groupBy(aliases, func(a models.CelebrityAlias) pgtype.UUID { return a.CelebrityID }), err
+		}),
+		work: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte]*models.Work, error) {
+			works, err := r.Works.FindByIDs(ctx, ids)
+			return byID(works, func(w models.Work) pgtype.UUID { return w.ID }), err
+		}),
+		categories: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte][]models.OscarCategory, error) {
+			categories, err := r.Oscars.GetCategoriesByCeremonies(ctx, ids)
+			return groupBy(categories, func(c models.OscarCategory) pgtype.UUID { return c.CeremonyID }), err
+		}),
+		nominees: newLoader(func(ctx context.Context, ids []pgtype.UUID) (map[[16]byte][]models.OscarNominee, error) {
+			nominees, err := r.Oscars.GetNomineesByCategories(ctx, ids)
+			return groupBy(nominees, func(n models.OscarNominee) pgtype.UUID { return n.CategoryID }), err
+		}),
+	}
+}
+
+func byID[T any](rows []T, id func(T) pgtype.UUID) map[[16]byte]*T {
+	result := make(map[[16]byte]*T, len(rows))
+	for i := range rows {
+		result[id(rows[i]).Bytes] = &rows[i]
+	}
+	return result
+}
+
+func groupBy[T any](rows []T, id func(T) pgtype.UUID) map[[16]byte][]T {
+	result := make(map[[16]byte][]T)
+	for _, row := range rows {
+		key := id(row).Bytes
+		result[key] = append(result[key], row)
+	}
+	return result
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// Repositories are the data sources the schema reads from
+type Repositories struct {
+	Celebrities *repository.CelebrityRepository
+	Awards      *repository.AwardRepository
+	Aliases     *repository.AliasRepository
+	Works       *repository.WorkRepository
+	Oscars      *repository.OscarRepository
+}
@@ -0,0 +1,529 @@
+package gql
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/repository"
+	"egot-tracker/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// defaultPageSize is the number of celebrities returned when a query does
+// not ask for a page size
+const defaultPageSize = 20
+
+// resolve adapts a function of the parent value to a field resolver
+func resolve[T any](f func(T) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return f(p.Source.(T)), nil
+	}
+}
+
+// newSchema builds the GraphQL schema. Object fields read from the models
+// package types; relations are resolved through the request's loaders.
+func newSchema(repos *Repositories, celebrities *service.CelebrityService) (graphql.Schema, error) {
+	awardTypeEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "AwardType",
+		Description: "One of the four EGOT awards",
+		Values: graphql.EnumValueConfigMap{
+			"Emmy":   &graphql.EnumValueConfig{Value: models.AwardTypeEmmy},
+			"Grammy": &graphql.EnumValueConfig{Value: models.AwardTypeGrammy},
+			"Oscar":  &graphql.EnumValueConfig{Value: models.AwardTypeOscar},
+			"Tony":   &graphql.EnumValueConfig{Value: models.AwardTypeTony},
+		},
+	})
+
+	sortEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "CelebritySort",
+		Values: graphql.EnumValueConfigMap{
+			"NAME":       &graphql.EnumValueConfig{Value: models.SortByName},
+			"RECENT_WIN": &graphql.EnumValueConfig{Value: models.SortByRecentWin},
+			"TOTAL_WINS": &graphql.EnumValueConfig{Value: models.SortByTotalWins},
+			"UPDATED":    &graphql.EnumValueConfig{Value: models.SortByUpdated},
+		},
+	})
+
+	lifeStatusEnum := graphql.NewEnum(graphql.EnumConfig{
+		Name: "LifeStatus",
+		Values: graphql.EnumValueConfigMap{
+			"LIVING":   &graphql.EnumValueConfig{Value: models.LifeStatusLiving},
+			"DECEASED": &graphql.EnumValueConfig{Value: models.LifeStatusDeceased},
+		},
+	})
+
+	// Celebrity, Award and Work refer to each other, so their fields are
+	// declared in thunks and filled in below
+	var celebrityType, awardType, workType *graphql.Object
+
+	aliasType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Alias",
+		Fields: graphql.Fields{
+			"alias":  &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(a models.CelebrityAlias) any { return a.Alias })},
+			"source": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(a models.CelebrityAlias) any { return string(a.Source) })},
+		},
+	})
+
+	progressType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "EGOTProgress",
+		Description: "Which EGOT awards a celebrity has won, counting past wins only",
+		Fields: graphql.Fields{
+			"winCount": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Int),
+				Resolve: resolve(func(t models.EGOTTimeline) any { return len(t.Milestones) }),
+			},
+			"wonAwards": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(awardTypeEnum))),
+				Resolve: resolve(func(t models.EGOTTimeline) any { return wonAwardTypes(t, true) }),
+			},
+			"missingAwards": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(awardTypeEnum))),
+				Resolve: resolve(func(t models.EGOTTimeline) any { return wonAwardTypes(t, false) }),
+			},
+			"order": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: `Letters in the order they were won, e.g. "G→E→T→O"`,
+				Resolve:     resolve(func(t models.EGOTTimeline) any { return t.Order }),
+			},
+			"isEGOT": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Resolve: resolve(func(t models.EGOTTimeline) any { return t.IsComplete }),
+			},
+			"completedYear": &graphql.Field{
+				Type: graphql.Int,
+				Resolve: resolve(func(t models.EGOTTimeline) any {
+					if t.CompletedYear == nil {
+						return nil
+					}
+					return *t.CompletedYear
+				}),
+			},
+		},
+	})
+
+	celebrityType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Celebrity",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolve(func(c models.Celebrity) any { return uuid(c.ID) })},
+				"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(c models.Celebrity) any { return c.Name })},
+				"slug":        &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(c models.Celebrity) any { return c.Slug })},
+				"photoUrl":    &graphql.Field{Type: graphql.String, Resolve: resolve(func(c models.Celebrity) any { return text(c.PhotoURL) })},
+				"summary":     &graphql.Field{Type: graphql.String, Resolve: resolve(func(c models.Celebrity) any { return text(c.Summary) })},
+				"deathDate":   &graphql.Field{Type: graphql.String, Resolve: resolve(func(c models.Celebrity) any { return date(c.DeathDate) })},
+				"lastUpdated": &graphql.Field{Type: graphql.String, Resolve: resolve(func(c models.Celebrity) any { return timestamp(c.LastUpdated.Time, c.LastUpdated.Valid) })},
+				"awards": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(awardType))),
+					Args: graphql.FieldConfigArgument{
+						"type":     &graphql.ArgumentConfig{Type: awardTypeEnum},
+						"winsOnly": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						c := p.Source.(models.Celebrity)
+						onlyType, _ := p.Args["type"].(models.AwardType)
+						winsOnly, _ := p.Args["winsOnly"].(bool)
+						load := loadersFrom(p.Context).awardsByCelebrity.load(p.Context, c.ID)
+						return thunk(load, func(awards []models.Award) any {
+							filtered := make([]models.Award, 0, len(awards))
+							for _, a := range awards {
+								if (onlyType == "" || a.Type == onlyType) && (!winsOnly || a.IsWinner) {
+									filtered = append(filtered, a)
+								}
+							}
+							return filtered
+						}), nil
+					},
+				},
+				"aliases": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(aliasType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						c := p.Source.(models.Celebrity)
+						load := loadersFrom(p.Context).aliasesByCelebrity.load(p.Context, c.ID)
+						return thunk(load, func(aliases []models.CelebrityAlias) any { return nonNil(aliases) }), nil
+					},
+				},
+				"egotProgress": &graphql.Field{
+					Type: graphql.NewNonNull(progressType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						c := p.Source.(models.Celebrity)
+						load := loadersFrom(p.Context).awardsByCelebrity.load(p.Context, c.ID)
+						return thunk(load, func(awards []models.Award) any { return models.BuildEGOTTimeline(awards) }), nil
+					},
+				},
+			}
+		}),
+	})
+
+	awardType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Award",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolve(func(a models.Award) any { return uuid(a.ID) })},
+				"type":         &graphql.Field{Type: graphql.NewNonNull(awardTypeEnum), Resolve: resolve(func(a models.Award) any { return a.Type })},
+				"year":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: resolve(func(a models.Award) any { return a.Year })},
+				"work":         &graphql.Field{Type: graphql.NewNonNull(graphql.String), Description: "Title of the work as credited", Resolve: resolve(func(a models.Award) any { return a.Work })},
+				"category":     &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(a models.Award) any { return a.Category })},
+				"isWinner":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: resolve(func(a models.Award) any { return a.IsWinner })},
+				"isUpcoming":   &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: resolve(func(a models.Award) any { return a.IsUpcoming })},
+				"ceremonyDate": &graphql.Field{Type: graphql.String, Resolve: resolve(func(a models.Award) any { return date(a.CeremonyDate) })},
+				"celebrity": &graphql.Field{
+					Type: celebrityType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						a := p.Source.(models.Award)
+						load := loadersFrom(p.Context).celebrity.load(p.Context, a.CelebrityID)
+						return thunk(load, celebrityOrNil), nil
+					},
+				},
+				"workDetails": &graphql.Field{
+					Type:        workType,
+					Description: "The linked work, if the award has been matched to one",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						a := p.Source.(models.Award)
+						if !a.WorkID.Valid {
+							return nil, nil
+						}
+						load := loadersFrom(p.Context).work.load(p.Context, a.WorkID)
+						return thunk(load, func(w *models.Work) any {
+							if w == nil {
+								return nil
+							}
+							return *w
+						}), nil
+					},
+				},
+			}
+		}),
+	})
+
+	workType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Work",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolve(func(w models.Work) any { return uuid(w.ID) })},
+				"wikidataId": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(w models.Work) any { return w.WikidataID })},
+				"title":      &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(w models.Work) any { return w.Title })},
+				"type":       &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(w models.Work) any { return string(w.Type) })},
+				"awards": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(awardType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						w := p.Source.(models.Work)
+						load := loadersFrom(p.Context).awardsByWork.load(p.Context, w.ID)
+						return thunk(load, func(awards []models.Award) any { return nonNil(awards) }), nil
+					},
+				},
+			}
+		}),
+	})
+
+	nomineeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OscarNominee",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolve(func(n models.OscarNominee) any { return uuid(n.ID) })},
+			"name":         &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(n models.OscarNominee) any { return n.Name })},
+			"photoUrl":     &graphql.Field{Type: graphql.String, Resolve: resolve(func(n models.OscarNominee) any { return text(n.PhotoURL) })},
+			"workTitle":    &graphql.Field{Type: graphql.String, Resolve: resolve(func(n models.OscarNominee) any { return text(n.WorkTitle) })},
+			"isWinner":     &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: resolve(func(n models.OscarNominee) any { return n.IsWinner })},
+			"displayOrder": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: resolve(func(n models.OscarNominee) any { return n.DisplayOrder })},
+			"celebrity": &graphql.Field{
+				Type:        celebrityType,
+				Description: "The tracked celebrity, if the nominee is one",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					n := p.Source.(models.OscarNominee)
+					if !n.CelebrityID.Valid {
+						return nil, nil
+					}
+					load := loadersFrom(p.Context).celebrity.load(p.Context, n.CelebrityID)
+					return thunk(load, celebrityOrNil), nil
+				},
+			},
+		},
+	})
+
+	categoryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OscarCategory",
+		Fields: graphql.Fields{
+			"id":              &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolve(func(c models.OscarCategory) any { return uuid(c.ID) })},
+			"name":            &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolve(func(c models.OscarCategory) any { return c.Name })},
+			"displayOrder":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: resolve(func(c models.OscarCategory) any { return c.DisplayOrder })},
+			"winnerAnnounced": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: resolve(func(c models.OscarCategory) any { return c.WinnerAnnounced })},
+			"nominees": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(nomineeType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c := p.Source.(models.OscarCategory)
+					load := loadersFrom(p.Context).nominees.load(p.Context, c.ID)
+					return thunk(load, func(nominees []models.OscarNominee) any { return nonNil(nominees) }), nil
+				},
+			},
+		},
+	})
+
+	ceremonyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OscarCeremony",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolve(func(c models.OscarCeremony) any { return uuid(c.ID) })},
+			"year":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: resolve(func(c models.OscarCeremony) any { return c.Year })},
+			"name":         &graphql.Field{Type: graphql.String, Resolve: resolve(func(c models.OscarCeremony) any { return text(c.CeremonyName) })},
+			"ceremonyDate": &graphql.Field{Type: graphql.String, Resolve: resolve(func(c models.OscarCeremony) any { return date(c.CeremonyDate) })},
+			"isComplete":   &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: resolve(func(c models.OscarCeremony) any { return c.IsComplete })},
+			"categories": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					c := p.Source.(models.OscarCeremony)
+					load := loadersFrom(p.Context).categories.load(p.Context, c.ID)
+					return thunk(load, func(categories []models.OscarCategory) any { return nonNil(categories) }), nil
+				},
+			},
+		},
+	})
+
+	celebrityPageType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CelebrityPage",
+		Fields: graphql.Fields{
+			"items": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(celebrityType))),
+				Resolve: resolve(func(page pagination.Page[models.CelebrityBrowseItem]) any {
+					items := make([]models.Celebrity, len(page.Items))
+					for i, item := range page.Items {
+						items[i] = item.Celebrity
+					}
+					return items
+				}),
+			},
+			"nextCursor": &graphql.Field{
+				Type: graphql.String,
+				Resolve: resolve(func(page pagination.Page[models.CelebrityBrowseItem]) any {
+					if page.NextCursor == nil {
+						return nil
+					}
+					return *page.NextCursor
+				}),
+			},
+			"totalCount": &graphql.Field{
+				Type:    graphql.NewNonNull(graphql.Int),
+				Resolve: resolve(func(page pagination.Page[models.CelebrityBrowseItem]) any { return int(page.TotalCount) }),
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"celebrity": &graphql.Field{
+				Type:        celebrityType,
+				Description: "Look a tracked celebrity up by ID or slug",
+				Args: graphql.FieldConfigArgument{
+					"id":   &graphql.ArgumentConfig{Type: graphql.ID},
+					"slug": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					var celebrity *models.Celebrity
+					var err error
+					switch id, slug := p.Args["id"], p.Args["slug"]; {
+					case id != nil && slug == nil:
+						var celebrityID pgtype.UUID
+						if err := celebrityID.Scan(id); err != nil || !celebrityID.Valid {
+							return nil, errors.New("invalid celebrity ID")
+						}
+						celebrity, err = repos.Celebrities.FindByID(p.Context, celebrityID)
+					case slug != nil && id == nil:
+						celebrity, err = repos.Celebrities.FindBySlug(p.Context, slug.(string))
+					default:
+						return nil, errors.New("exactly one of id or slug is required")
+					}
+					if errors.Is(err, repository.ErrCelebrityNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					return *celebrity, nil
+				},
+			},
+			"celebrities": &graphql.Field{
+				Type:        graphql.NewNonNull(celebrityPageType),
+				Description: "Browse tracked celebrities, with the same filters as /api/v1/celebrities",
+				Args: graphql.FieldConfigArgument{
+					"minWins":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"maxWins":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"missing":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(awardTypeEnum))},
+					"wonFrom":   &graphql.ArgumentConfig{Type: graphql.Int},
+					"wonTo":     &graphql.ArgumentConfig{Type: graphql.Int},
+					"status":    &graphql.ArgumentConfig{Type: lifeStatusEnum},
+					"hasAwards": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"sort":      &graphql.ArgumentConfig{Type: sortEnum, DefaultValue: models.SortByName},
+					"first":     &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+					"after":     &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter, page, err := browseArgs(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return celebrities.BrowsePage(p.Context, filter, page)
+				},
+			},
+			"work": &graphql.Field{
+				Type:        workType,
+				Description: "Look a work up by UUID or Wikidata ID",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					id := p.Args["id"].(string)
+
+					var work *models.Work
+					var err error
+					if service.IsWikidataID(id) {
+						work, err = repos.Works.FindByWikidataID(p.Context, id)
+					} else {
+						var workID pgtype.UUID
+						if err := workID.Scan(id); err != nil || !workID.Valid {
+							return nil, errors.New("invalid work ID")
+						}
+						work, err = repos.Works.FindByID(p.Context, workID)
+					}
+					if errors.Is(err, repository.ErrWorkNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					return *work, nil
+				},
+			},
+			"oscarCeremony": &graphql.Field{
+				Type: ceremonyType,
+				Args: graphql.FieldConfigArgument{
+					"year": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					ceremony, err := repos.Oscars.GetCeremonyByYear(p.Context, p.Args["year"].(int))
+					if errors.Is(err, repository.ErrCeremonyNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					return *ceremony, nil
+				},
+			},
+			"oscarCeremonyYears": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.Int))),
+				Description: "Tracked Oscar years, most recent first",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					years, _, err := repos.Oscars.GetAllCeremonyYears(p.Context, pagination.Params{Limit: pagination.MaxLimit})
+					return nonNil(years), err
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// browseArgs converts the arguments of Query.celebrities to a browse filter
+// and page
+func browseArgs(args map[string]interface{}) (models.CelebrityFilter, pagination.Params, error) {
+	intArg := func(name string) *int {
+		if value, ok := args[name].(int); ok {
+			return &value
+		}
+		return nil
+	}
+
+	filter := models.CelebrityFilter{
+		MinWins: intArg("minWins"),
+		MaxWins: intArg("maxWins"),
+		WonFrom: intArg("wonFrom"),
+		WonTo:   intArg("wonTo"),
+	}
+	if missing, ok := args["missing"].([]interface{}); ok {
+		for _, awardType := range missing {
+			filter.Missing = append(filter.Missing, awardType.(models.AwardType))
+		}
+	}
+	if status, ok := args["status"].(models.LifeStatus); ok {
+		filter.Status = status
+	}
+	if hasAwards, ok := args["hasAwards"].(bool); ok {
+		filter.HasAwards = &hasAwards
+	}
+	filter.Sort = args["sort"].(models.CelebritySort)
+
+	page := pagination.Params{Limit: args["first"].(int), Scope: service.BrowseCursors(filter)}
+	if page.Limit < 1 || page.Limit > pagination.MaxLimit {
+		return filter, page, fmt.Errorf("first must be between 1 and %d", pagination.MaxLimit)
+	}
+	if after, ok := args["after"].(string); ok {
+		cursor, err := page.Scope.Decode(after)
+		if err != nil {
+			return filter, page, errors.New("invalid cursor")
+		}
+		page.Cursor = cursor
+	}
+
+	return filter, page, nil
+}
+
+// wonAwardTypes lists, in EGOT order, the award types a timeline has won or
+// is still missing
+func wonAwardTypes(t models.EGOTTimeline, won bool) []models.AwardType {
+	types := make([]models.AwardType, 0, len(models.AllAwardTypes))
+	for _, awardType := range models.AllAwardTypes {
+		hasWon := slices.ContainsFunc(t.Milestones, func(m models.EGOTMilestone) bool { return m.Type == awardType })
+		if hasWon == won {
+			types = append(types, awardType)
+		}
+	}
+	return types
+}
+
+func celebrityOrNil(c *models.Celebrity) any {
+	if c == nil {
+		return nil
+	}
+	return *c
+}
+
+// nonNil turns a nil slice into an empty one, since list fields are non-null
+func nonNil[T any](rows []T) []T {
+	if rows == nil {
+		return []T{}
+	}
+	return rows
+}
+
+func uuid(id pgtype.UUID) any {
+	if !id.Valid {
+		return nil
+	}
+	value, _ := id.Value()
+	return value
+}
+
+func text(t pgtype.Text) any {
+	if !t.Valid {
+		return nil
+	}
+	return t.String
+}
+
+func date(d pgtype.Date) any {
+	if !d.Valid {
+		return nil
+	}
+	return d.Time.Format("2006-01-02")
+}
+
+func timestamp(t time.Time, valid bool) any {
+	if !valid {
+		return nil
+	}
+	return t.Format(time.RFC3339)
+}
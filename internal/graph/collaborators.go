@@ -0,0 +1,150 @@
+// Package graph holds an in-memory graph of celebrities connected by the
+// works they won awards for.
+package graph
+
+import (
+	"sort"
+
+	"egot-tracker/internal/models"
+)
+
+type nodeID = [16]byte
+
+// CollaboratorGraph is a bipartite graph of celebrities and works, with an
+// edge wherever a celebrity won an award for a work. It is immutable once
+// built and safe for concurrent use.
+type CollaboratorGraph struct {
+	celebrities       map[nodeID]models.Celebrity
+	works             map[nodeID]models.Work
+	worksByCelebrity  map[nodeID][]nodeID
+	celebritiesByWork map[nodeID][]nodeID
+}
+
+// New builds a graph from award wins. Adjacency lists are sorted by name and
+// title so that results are deterministic.
+func New(wins []models.WorkWin) *CollaboratorGraph {
+	g := &CollaboratorGraph{
+		celebrities:       make(map[nodeID]models.Celebrity),
+		works:             make(map[nodeID]models.Work),
+		worksByCelebrity:  make(map[nodeID][]nodeID),
+		celebritiesByWork: make(map[nodeID][]nodeID),
+	}
+
+	for _, win := range wins {
+		c, w := win.Celebrity.ID.Bytes, win.Work.ID.Bytes
+		g.celebrities[c] = win.Celebrity
+		g.works[w] = win.Work
+		g.worksByCelebrity[c] = append(g.worksByCelebrity[c], w)
+		g.celebritiesByWork[w] = append(g.celebritiesByWork[w], c)
+	}
+
+	for _, ids := range g.worksByCelebrity {
+		sort.Slice(ids, func(i, j int) bool {
+			return g.works[ids[i]].Title < g.works[ids[j]].Title
+		})
+	}
+	for _, ids := range g.celebritiesByWork {
+		sort.Slice(ids, func(i, j int) bool {
+			return g.celebrities[ids[i]].Name < g.celebrities[ids[j]].Name
+		})
+	}
+
+	return g
+}
+
+// Collaborators returns everyone who won an award for a work the celebrity
+// also won for, with the works they share, most shared works first and then
+// by name
+func (g *CollaboratorGraph) Collaborators(celebrityID nodeID) []models.Collaborator {
+	shared := make(map[nodeID][]models.Work)
+	var order []nodeID
+	for _, w := range g.worksByCelebrity[celebrityID] {
+		for _, c := range g.celebritiesByWork[w] {
+			if c == celebrityID {
+				continue
+			}
+			if _, seen := shared[c]; !seen {
+				order = append(order, c)
+			}
+			shared[c] = append(shared[c], g.works[w])
+		}
+	}
+
+	collaborators := make([]models.Collaborator, len(order))
+	for i, c := range order {
+		collaborators[i] = models.Collaborator{
+			Celebrity:   g.celebrities[c],
+			SharedWorks: shared[c],
+		}
+	}
+	sort.Slice(collaborators, func(i, j int) bool {
+		a, b := collaborators[i], collaborators[j]
+		if len(a.SharedWorks) != len(b.SharedWorks) {
+			return len(a.SharedWorks) > len(b.SharedWorks)
+		}
+		return a.Name < b.Name
+	})
+
+	return collaborators
+}
+
+// ShortestPath returns the shortest chain of shared award-winning works from
+// one celebrity to another, and false if they are not connected. A
+// celebrity is connected to themselves by an empty chain.
+func (g *CollaboratorGraph) ShortestPath(from, to nodeID) ([]models.PathStep, bool) {
+	if from == to {
+		return []models.PathStep{}, true
+	}
+
+	// Breadth-first search over celebrities, remembering for each one the
+	// celebrity and work it was reached through
+	reachedVia := map[nodeID]hop{from: {}}
+	queue := []nodeID{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, w := range g.worksByCelebrity[current] {
+			for _, next := range g.celebritiesByWork[w] {
+				if _, seen := reachedVia[next]; seen {
+					continue
+				}
+				reachedVia[next] = hop{celebrity: current, work: w}
+				if next == to {
+					return g.buildPath(from, to, reachedVia), true
+				}
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// hop is the celebrity and shared work through which a search reached a
+// celebrity
+type hop struct {
+	celebrity nodeID
+	work      nodeID
+}
+
+// buildPath walks the search's hops back from to to from, and returns the
+// steps in order
+func (g *CollaboratorGraph) buildPath(from, to nodeID, reachedVia map[nodeID]hop) []models.PathStep {
+	var steps []models.PathStep
+	for c := to; c != from; {
+		h := reachedVia[c]
+		steps = append(steps, models.PathStep{
+			From: g.celebrities[h.celebrity],
+			Work: g.works[h.work],
+			To:   g.celebrities[c],
+		})
+		c = h.celebrity
+	}
+
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
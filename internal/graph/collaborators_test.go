@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func id(n byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: n}, Valid: true}
+}
+
+var (
+	alice = models.Celebrity{ID: id(1), Name: "Alice"}
+	bob   = models.Celebrity{ID: id(2), Name: "Bob"}
+	carol = models.Celebrity{ID: id(3), Name: "Carol"}
+	dave  = models.Celebrity{ID: id(4), Name: "Dave"}
+	erin  = models.Celebrity{ID: id(5), Name: "Erin"}
+	frank = models.Celebrity{ID: id(6), Name: "Frank"}
+	grace = models.Celebrity{ID: id(7), Name: "Grace"}
+
+	alpha   = models.Work{ID: id(101), Title: "Alpha"}
+	beta    = models.Work{ID: id(102), Title: "Beta"}
+	gamma   = models.Work{ID: id(103), Title: "Gamma"}
+	delta   = models.Work{ID: id(104), Title: "Delta"}
+	epsilon = models.Work{ID: id(105), Title: "Epsilon"}
+)
+
+// testGraph connects Alice to Erin through Bob and Carol; Frank and Grace
+// only know each other
+func testGraph() *CollaboratorGraph {
+	return New([]models.WorkWin{
+		{Celebrity: alice, Work: gamma},
+		{Celebrity: bob, Work: gamma},
+		{Celebrity: alice, Work: alpha},
+		{Celebrity: bob, Work: alpha},
+		{Celebrity: bob, Work: beta},
+		{Celebrity: dave, Work: beta},
+		{Celebrity: carol, Work: beta},
+		{Celebrity: carol, Work: epsilon},
+		{Celebrity: erin, Work: epsilon},
+		{Celebrity: frank, Work: delta},
+		{Celebrity: grace, Work: delta},
+	})
+}
+
+// names returns the names of collaborators and the titles of the works
+// they share, e.g. "Alice: Alpha, Gamma"
+func names(collaborators []models.Collaborator) []string {
+	out := []string{}
+	for _, c := range collaborators {
+		titles := make([]string, len(c.SharedWorks))
+		for i, w := range c.SharedWorks {
+			titles[i] = w.Title
+		}
+		out = append(out, c.Name+": "+strings.Join(titles, ", "))
+	}
+	return out
+}
+
+func TestCollaborators(t *testing.T) {
+	g := testGraph()
+
+	tests := []struct {
+		name      string
+		celebrity models.Celebrity
+		want      []string
+	}{
+		{"most shared works first, then by name", bob, []string{"Alice: Alpha, Gamma", "Carol: Beta", "Dave: Beta"}},
+		{"shared works in title order", alice, []string{"Bob: Alpha, Gamma"}},
+		{"one shared work", erin, []string{"Carol: Epsilon"}},
+		{"unknown celebrity", models.Celebrity{ID: id(99)}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := names(g.Collaborators(tt.celebrity.ID.Bytes))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Collaborators(%s) = %q, want %q", tt.celebrity.Name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := testGraph()
+
+	tests := []struct {
+		name      string
+		from, to  models.Celebrity
+		want      []string // "From -Work-> To" for each step
+		connected bool
+	}{
+		{
+			name:      "direct collaborators",
+			from:      alice,
+			to:        bob,
+			want:      []string{"Alice -Alpha-> Bob"},
+			connected: true,
+		},
+		{
+			name:      "through shared works",
+			from:      alice,
+			to:        erin,
+			want:      []string{"Alice -Alpha-> Bob", "Bob -Beta-> Carol", "Carol -Epsilon-> Erin"},
+			connected: true,
+		},
+		{
+			name:      "reverse direction",
+			from:      erin,
+			to:        dave,
+			want:      []string{"Erin -Epsilon-> Carol", "Carol -Beta-> Dave"},
+			connected: true,
+		},
+		{
+			name:      "source is the target",
+			from:      carol,
+			to:        carol,
+			want:      []string{},
+			connected: true,
+		},
+		{
+			name: "unreachable",
+			from: alice,
+			to:   frank,
+		},
+		{
+			name: "unknown celebrity",
+			from: alice,
+			to:   models.Celebrity{ID: id(99)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, connected := g.ShortestPath(tt.from.ID.Bytes, tt.to.ID.Bytes)
+			if connected != tt.connected {
+				t.Fatalf("connected = %v, want %v", connected, tt.connected)
+			}
+			if !connected {
+				if steps != nil {
+					t.Errorf("steps = %v, want nil", steps)
+				}
+				return
+			}
+
+			got := []string{}
+			for _, s := range steps {
+				got = append(got, s.From.Name+" -"+s.Work.Title+"-> "+s.To.Name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("path = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
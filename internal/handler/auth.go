@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/service"
+)
+
+type contextKey int
+
+const apiKeyContextKey contextKey = iota
+
+// RequireAPIKey wraps a handler so it only runs for requests carrying an
+// active API key as "Authorization: Bearer <key>". The key is available to
+// the handler through apiKeyFrom.
+func RequireAPIKey(watchlists *service.WatchlistService, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, r, service.ErrAPIKeyRequired)
+			return
+		}
+
+		apiKey, err := watchlists.Authenticate(r.Context(), strings.TrimSpace(token))
+		if errors.Is(err, service.ErrInvalidAPIKey) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, r, err)
+			return
+		}
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), apiKeyContextKey, apiKey)))
+	}
+}
+
+// RequireAdminKey is RequireAPIKey for routes that curate shared data, which
+// only admin keys may use
+func RequireAdminKey(watchlists *service.WatchlistService, next http.HandlerFunc) http.HandlerFunc {
+	return RequireAPIKey(watchlists, func(w http.ResponseWriter, r *http.Request) {
+		if !apiKeyFrom(r).IsAdmin {
+			writeError(w, r, service.ErrAdminRequired)
+			return
+		}
+		next(w, r)
+	})
+}
+
+// apiKeyFrom returns the API key authenticated by RequireAPIKey
+func apiKeyFrom(r *http.Request) *models.APIKey {
+	apiKey, _ := r.Context().Value(apiKeyContextKey).(*models.APIKey)
+	return apiKey
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"egot-tracker/internal/ical"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/service"
+)
+
+type CalendarHandler struct {
+	service *service.CalendarService
+}
+
+func NewCalendarHandler(service *service.CalendarService) *CalendarHandler {
+	return &CalendarHandler{service: service}
+}
+
+// Ceremonies handles GET /calendar/ceremonies.ics
+//
+// Filters: close_to_egot=true keeps ceremonies where someone with 3 of the
+// 4 awards is nominated; token=CALENDARTOKEN keeps ceremonies where someone
+// on the watchlist the token was issued for is nominated. Calendar apps
+// cannot send headers, so the token goes in the query string; it only reads
+// this feed, and API keys are refused here. Both filters may be combined.
+func (h *CalendarHandler) Ceremonies(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	var filter models.CeremonyFilter
+
+	if closeToEGOT := q.Get("close_to_egot"); closeToEGOT != "" {
+		parsed, err := strconv.ParseBool(closeToEGOT)
+		if err != nil {
+			writeError(w, r, service.InvalidInput("close_to_egot", "invalid close_to_egot"))
+			return
+		}
+		filter.CloseToEGOT = parsed
+	}
+
+	if q.Has("key") {
+		writeError(w, r, service.ErrCalendarTokenRequired)
+		return
+	}
+
+	if token := q.Get("token"); token != "" {
+		var err error
+		filter.Celebrities, err = h.service.WatchlistCelebrities(r.Context(), token)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	calendar, err := h.service.UpcomingCeremonies(r.Context(), filter)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, *calendar); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="ceremonies.ics"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
@@ -1,14 +1,19 @@
 package handler
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
+	v1 "egot-tracker/internal/api/v1"
 	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
 	"egot-tracker/internal/service"
 	"egot-tracker/pkg/response"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type CelebrityHandler struct {
@@ -23,106 +28,257 @@ func (h *CelebrityHandler) Search(w http.ResponseWriter, r *http.Request) {
 	// Validate query parameter
 	query := strings.TrimSpace(r.URL.Query().Get("q"))
 	if query == "" {
-		response.Error(w, http.StatusBadRequest, "query parameter 'q' is required")
+		writeError(w, r, service.InvalidInput("q", "query parameter 'q' is required"))
 		return
 	}
 
 	// Call service layer
 	result, err := h.service.SearchCelebrity(r.Context(), query)
-	if errors.Is(err, service.ErrCelebrityNotFound) {
-		response.Error(w, http.StatusNotFound, "celebrity not found")
-		return
-	}
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, result)
+	response.JSON(w, http.StatusOK, v1.NewCelebrityWithAwards(*result))
 }
 
 func (h *CelebrityHandler) Autocomplete(w http.ResponseWriter, r *http.Request) {
 	query := strings.TrimSpace(r.URL.Query().Get("q"))
 	if query == "" {
-		response.JSON(w, http.StatusOK, []models.Celebrity{})
+		response.JSON(w, http.StatusOK, []v1.Celebrity{})
 		return
 	}
 
 	results, err := h.service.Autocomplete(r.Context(), query, 10)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.List(results, v1.NewCelebrity))
+}
+
+// Browse handles GET /api/v1/celebrities
+//
+// Filters: min_wins, max_wins, missing (comma-separated award types),
+// needs (shorthand for "3 wins, missing this one"), won_from, won_to,
+// status (living|deceased), has_awards (true|false).
+// Sort: name (default), recent_win, total_wins, updated.
+func (h *CelebrityHandler) Browse(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseCelebrityFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := pagination.ParseParams(r, service.BrowseCursors(filter))
+	if err != nil {
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
 		return
 	}
 
-	if results == nil {
-		results = []models.Celebrity{}
+	results, err := h.service.Browse(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
-	response.JSON(w, http.StatusOK, results)
+	response.JSON(w, http.StatusOK, v1.NewBrowseResult(results.Page, results.Facets))
 }
 
-// CloseToEGOT handles GET /api/celebrity/close-to-egot
-func (h *CelebrityHandler) CloseToEGOT(w http.ResponseWriter, r *http.Request) {
-	limit := 50
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
-			limit = parsed
+// parseCelebrityFilter reads browse filters from the query string
+func parseCelebrityFilter(r *http.Request) (models.CelebrityFilter, error) {
+	q := r.URL.Query()
+	var filter models.CelebrityFilter
+
+	intParam := func(name string) (*int, error) {
+		value := q.Get(name)
+		if value == "" {
+			return nil, nil
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, service.InvalidInput(name, "invalid "+name)
+		}
+		return &parsed, nil
+	}
+
+	var err error
+	if filter.MinWins, err = intParam("min_wins"); err != nil {
+		return filter, err
+	}
+	if filter.MaxWins, err = intParam("max_wins"); err != nil {
+		return filter, err
+	}
+	if filter.WonFrom, err = intParam("won_from"); err != nil {
+		return filter, err
+	}
+	if filter.WonTo, err = intParam("won_to"); err != nil {
+		return filter, err
+	}
+
+	if missing := q.Get("missing"); missing != "" {
+		for _, name := range strings.Split(missing, ",") {
+			awardType, ok := parseAwardType(name)
+			if !ok {
+				return filter, service.InvalidInput("missing", fmt.Sprintf("invalid award type %q", strings.TrimSpace(name)))
+			}
+			filter.Missing = append(filter.Missing, awardType)
+		}
+	}
+
+	// "needs=Tony" means everything but a Tony has been won
+	if needs := q.Get("needs"); needs != "" {
+		awardType, ok := parseAwardType(needs)
+		if !ok {
+			return filter, service.InvalidInput("needs", fmt.Sprintf("invalid award type %q", needs))
+		}
+		three := 3
+		filter.MinWins, filter.MaxWins = &three, &three
+		filter.Missing = append(filter.Missing, awardType)
+	}
+
+	switch status := models.LifeStatus(q.Get("status")); status {
+	case "", models.LifeStatusLiving, models.LifeStatusDeceased:
+		filter.Status = status
+	default:
+		return filter, service.InvalidInput("status", fmt.Sprintf("invalid status %q", status))
+	}
+
+	if hasAwards := q.Get("has_awards"); hasAwards != "" {
+		parsed, err := strconv.ParseBool(hasAwards)
+		if err != nil {
+			return filter, service.InvalidInput("has_awards", "invalid has_awards")
+		}
+		filter.HasAwards = &parsed
+	}
+
+	switch sort := models.CelebritySort(q.Get("sort")); sort {
+	case "":
+		filter.Sort = models.SortByName
+	case models.SortByName, models.SortByRecentWin, models.SortByTotalWins, models.SortByUpdated:
+		filter.Sort = sort
+	default:
+		return filter, service.InvalidInput("sort", fmt.Sprintf("invalid sort %q", sort))
+	}
+
+	return filter, nil
+}
+
+// parseAwardType matches an award type name case-insensitively
+func parseAwardType(name string) (models.AwardType, bool) {
+	name = strings.TrimSpace(name)
+	for _, awardType := range models.AllAwardTypes {
+		if strings.EqualFold(name, string(awardType)) {
+			return awardType, true
 		}
 	}
+	return "", false
+}
+
+// EGOTWatch handles GET /api/v1/egot-watch
+func (h *CelebrityHandler) EGOTWatch(w http.ResponseWriter, r *http.Request) {
+	ceremonies, err := h.service.GetEGOTWatch(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
 
-	results, err := h.service.GetCloseToEGOT(r.Context(), limit)
+	response.JSON(w, http.StatusOK, v1.List(ceremonies, v1.NewUpcomingCeremony))
+}
+
+// CloseToEGOT handles GET /api/v1/celebrity/close-to-egot
+func (h *CelebrityHandler) CloseToEGOT(w http.ResponseWriter, r *http.Request) {
+	page, err := pagination.ParseParams(r, service.CloseToEGOTCursors)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
 		return
 	}
 
-	if results == nil {
-		results = []models.CelebrityWithEGOTProgress{}
+	results, err := h.service.GetCloseToEGOT(r.Context(), page)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
-	response.JSON(w, http.StatusOK, results)
+	response.JSON(w, http.StatusOK, pagination.Map(results, v1.NewCelebrityWithProgress))
 }
 
-// EGOTWinners handles GET /api/celebrity/egot-winners
+// EGOTWinners handles GET /api/v1/celebrity/egot-winners
 func (h *CelebrityHandler) EGOTWinners(w http.ResponseWriter, r *http.Request) {
-	limit := 50
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
-			limit = parsed
-		}
+	page, err := pagination.ParseParams(r, service.EGOTWinnersCursors)
+	if err != nil {
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
+		return
 	}
 
-	results, err := h.service.GetEGOTWinners(r.Context(), limit)
+	results, err := h.service.GetEGOTWinners(r.Context(), page)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, err)
 		return
 	}
 
-	if results == nil {
-		results = []models.CelebrityWithEGOTProgress{}
+	response.JSON(w, http.StatusOK, pagination.Map(results, v1.NewCelebrityWithProgress))
+}
+
+// NoAwards handles GET /api/v1/celebrity/no-awards
+func (h *CelebrityHandler) NoAwards(w http.ResponseWriter, r *http.Request) {
+	page, err := pagination.ParseParams(r, service.NoAwardsCursors)
+	if err != nil {
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
+		return
 	}
 
-	response.JSON(w, http.StatusOK, results)
+	results, err := h.service.GetNoAwards(r.Context(), page)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pagination.Map(results, v1.NewCelebrity))
 }
 
-// NoAwards handles GET /api/celebrity/no-awards
-func (h *CelebrityHandler) NoAwards(w http.ResponseWriter, r *http.Request) {
-	limit := 50
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
-			limit = parsed
-		}
+// GetAliases handles GET /api/v1/celebrity/{id}/aliases
+func (h *CelebrityHandler) GetAliases(w http.ResponseWriter, r *http.Request) {
+	var celebrityID pgtype.UUID
+	if err := celebrityID.Scan(r.PathValue("id")); err != nil || !celebrityID.Valid {
+		writeError(w, r, service.InvalidInput("id", "invalid celebrity ID"))
+		return
 	}
 
-	results, err := h.service.GetNoAwards(r.Context(), limit)
+	aliases, err := h.service.GetAliases(r.Context(), celebrityID)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, err)
 		return
 	}
 
-	if results == nil {
-		results = []models.Celebrity{}
+	response.JSON(w, http.StatusOK, v1.List(aliases, v1.NewAlias))
+}
+
+// addAliasRequest is the body of POST /api/v1/celebrity/{id}/aliases
+type addAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// AddAlias handles POST /api/v1/celebrity/{id}/aliases
+func (h *CelebrityHandler) AddAlias(w http.ResponseWriter, r *http.Request) {
+	var celebrityID pgtype.UUID
+	if err := celebrityID.Scan(r.PathValue("id")); err != nil || !celebrityID.Valid {
+		writeError(w, r, service.InvalidInput("id", "invalid celebrity ID"))
+		return
+	}
+
+	var req addAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, service.InvalidInput("body", "invalid request body"))
+		return
+	}
+
+	alias, err := h.service.AddAlias(r.Context(), celebrityID, req.Alias)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
-	response.JSON(w, http.StatusOK, results)
+	response.JSON(w, http.StatusCreated, v1.NewAlias(*alias))
 }
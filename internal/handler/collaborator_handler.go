@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CollaboratorHandler struct {
+	service *service.CollaboratorService
+}
+
+func NewCollaboratorHandler(service *service.CollaboratorService) *CollaboratorHandler {
+	return &CollaboratorHandler{service: service}
+}
+
+// GetCollaborators handles GET /api/v1/celebrity/{id}/collaborators
+func (h *CollaboratorHandler) GetCollaborators(w http.ResponseWriter, r *http.Request) {
+	var celebrityID pgtype.UUID
+	if err := celebrityID.Scan(r.PathValue("id")); err != nil || !celebrityID.Valid {
+		writeError(w, r, service.InvalidInput("id", "invalid celebrity ID"))
+		return
+	}
+
+	collaborators, err := h.service.GetCollaborators(r.Context(), celebrityID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.List(collaborators, v1.NewCollaborator))
+}
+
+// GetPath handles GET /api/v1/path?from=ID&to=ID
+func (h *CollaboratorHandler) GetPath(w http.ResponseWriter, r *http.Request) {
+	var fromID, toID pgtype.UUID
+	if err := fromID.Scan(r.URL.Query().Get("from")); err != nil || !fromID.Valid {
+		writeError(w, r, service.InvalidInput("from", "query parameter 'from' must be a celebrity ID"))
+		return
+	}
+	if err := toID.Scan(r.URL.Query().Get("to")); err != nil || !toID.Valid {
+		writeError(w, r, service.InvalidInput("to", "query parameter 'to' must be a celebrity ID"))
+		return
+	}
+
+	path, err := h.service.GetPath(r.Context(), fromID, toID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.NewCollaborationPath(*path))
+}
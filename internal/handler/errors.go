@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+)
+
+// statusByKind maps domain error kinds to HTTP statuses
+var statusByKind = map[service.ErrorKind]int{
+	service.KindInternal:     http.StatusInternalServerError,
+	service.KindInvalid:      http.StatusBadRequest,
+	service.KindUnauthorized: http.StatusUnauthorized,
+	service.KindForbidden:    http.StatusForbidden,
+	service.KindNotFound:     http.StatusNotFound,
+	service.KindConflict:     http.StatusConflict,
+	service.KindUnavailable:  http.StatusServiceUnavailable,
+}
+
+// writeError responds with err as a problem. Domain errors keep their code,
+// message and field details; anything else is logged and reported as an
+// internal error, without details.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *service.Error
+	if !errors.As(err, &domainErr) {
+		logging.FromContext(r.Context()).Error("request failed", "error", err)
+		domainErr = service.ErrInternal
+	}
+
+	status, ok := statusByKind[domainErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	response.WriteProblem(w, status, domainErr.Code, domainErr.Message, domainErr.Fields)
+}
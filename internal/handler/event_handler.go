@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+)
+
+type EventHandler struct {
+	service *service.EventService
+}
+
+func NewEventHandler(service *service.EventService) *EventHandler {
+	return &EventHandler{service: service}
+}
+
+// List handles GET /api/v1/events
+//
+// Filters: type (comma-separated first_win|reached_three|completed_egot),
+// celebrity_id, exclude_initial (true to skip milestones found on a
+// celebrity's first import).
+func (h *EventHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := pagination.ParseParams(r, service.EventCursors)
+	if err != nil {
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
+		return
+	}
+
+	events, err := h.service.GetEvents(r.Context(), filter, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pagination.Map(events, v1.NewEGOTEvent))
+}
+
+// parseEventFilter reads event filters from the query string
+func parseEventFilter(r *http.Request) (models.EGOTEventFilter, error) {
+	q := r.URL.Query()
+	var filter models.EGOTEventFilter
+
+	if types := q.Get("type"); types != "" {
+		for _, name := range strings.Split(types, ",") {
+			switch eventType := models.EGOTEventType(strings.TrimSpace(name)); eventType {
+			case models.EventFirstWin, models.EventReachedThree, models.EventCompletedEGOT:
+				filter.Types = append(filter.Types, eventType)
+			default:
+				return filter, service.InvalidInput("type", fmt.Sprintf("invalid event type %q", eventType))
+			}
+		}
+	}
+
+	if celebrityID := q.Get("celebrity_id"); celebrityID != "" {
+		if err := filter.CelebrityID.Scan(celebrityID); err != nil || !filter.CelebrityID.Valid {
+			return filter, service.InvalidInput("celebrity_id", "invalid celebrity_id")
+		}
+	}
+
+	if excludeInitial := q.Get("exclude_initial"); excludeInitial != "" {
+		parsed, err := strconv.ParseBool(excludeInitial)
+		if err != nil {
+			return filter, service.InvalidInput("exclude_initial", "invalid exclude_initial")
+		}
+		filter.ExcludeInitial = parsed
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+
+	"egot-tracker/internal/feed"
+	"egot-tracker/internal/service"
+)
+
+type FeedHandler struct {
+	service *service.FeedService
+}
+
+func NewFeedHandler(service *service.FeedService) *FeedHandler {
+	return &FeedHandler{service: service}
+}
+
+// RecentAwards handles GET /feeds/awards.atom and GET /feeds/awards.rss
+func (h *FeedHandler) RecentAwards(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, r.URL.Path, h.service.RecentAwards)
+}
+
+// CompletedEGOTs handles GET /feeds/egot.atom and GET /feeds/egot.rss
+func (h *FeedHandler) CompletedEGOTs(w http.ResponseWriter, r *http.Request) {
+	h.serve(w, r, r.URL.Path, h.service.CompletedEGOTs)
+}
+
+// Celebrity handles GET /feeds/celebrity/{slug}.atom and
+// GET /feeds/celebrity/{slug}.rss
+func (h *FeedHandler) Celebrity(w http.ResponseWriter, r *http.Request) {
+	file := r.PathValue("file")
+	slug := strings.TrimSuffix(strings.TrimSuffix(file, ".atom"), ".rss")
+	h.serve(w, r, file, func(ctx context.Context) (*feed.Feed, error) {
+		return h.service.CelebrityFeed(ctx, slug)
+	})
+}
+
+// serve builds a feed and writes it in the format named by the extension of
+// name. Last-Modified is set from the feed, so unchanged feeds are answered
+// with 304 Not Modified.
+func (h *FeedHandler) serve(w http.ResponseWriter, r *http.Request, name string, build func(ctx context.Context) (*feed.Feed, error)) {
+	var write func(w *bytes.Buffer, f feed.Feed) error
+	var contentType string
+	switch {
+	case strings.HasSuffix(name, ".atom"):
+		write = func(w *bytes.Buffer, f feed.Feed) error { return feed.WriteAtom(w, f) }
+		contentType = "application/atom+xml; charset=utf-8"
+	case strings.HasSuffix(name, ".rss"):
+		write = func(w *bytes.Buffer, f feed.Feed) error { return feed.WriteRSS(w, f) }
+		contentType = "application/rss+xml; charset=utf-8"
+	default:
+		writeError(w, r, service.ErrFeedNotFound)
+		return
+	}
+
+	f, err := build(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	f.SelfLink = requestURL(r)
+
+	var buf bytes.Buffer
+	if err := write(&buf, *f); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	http.ServeContent(w, r, "", f.Updated, bytes.NewReader(buf.Bytes()))
+}
+
+// requestURL reconstructs the absolute URL a request was made to, honouring
+// X-Forwarded-Proto from a reverse proxy
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
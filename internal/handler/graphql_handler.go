@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"egot-tracker/internal/gql"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+)
+
+type GraphQLHandler struct {
+	executor *gql.Executor
+}
+
+func NewGraphQLHandler(executor *gql.Executor) *GraphQLHandler {
+	return &GraphQLHandler{executor: executor}
+}
+
+// Query handles GET and POST /graphql. POST takes a JSON body with query,
+// variables and operationName; GET takes the same as query parameters.
+// Query errors are reported in the result's errors list with status 200.
+func (h *GraphQLHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var req gql.Request
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeError(w, r, service.InvalidInput("variables", "invalid variables"))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, service.InvalidInput("body", "invalid request body"))
+		return
+	}
+
+	if req.Query == "" {
+		writeError(w, r, service.InvalidInput("query", "query is required"))
+		return
+	}
+
+	response.JSON(w, http.StatusOK, h.executor.Execute(r.Context(), req))
+}
@@ -0,0 +1,326 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/gql"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/openapi"
+	"egot-tracker/internal/pagination"
+
+	"github.com/graphql-go/graphql"
+)
+
+// APIInfo describes the API in the OpenAPI document
+var APIInfo = openapi.Info{
+	Title:       "EGOT Tracker API",
+	Version:     "1.0.0",
+	Description: "Emmy, Grammy, Oscar and Tony winners and nominees, scraped from Wikidata.",
+}
+
+// Enums lists the values of the string types used in requests and responses
+var Enums = []any{
+	models.AllAwardTypes,
+	models.AllLeaderboardMetrics,
+	[]models.AwardSource{models.AwardSourceSeed, models.AwardSourceWikidata, models.AwardSourceManual},
+	[]models.AliasSource{models.AliasSourceWikidata, models.AliasSourceManual},
+	[]models.WorkType{models.WorkTypeFilm, models.WorkTypeSeries, models.WorkTypeAlbum, models.WorkTypePlay, models.WorkTypeOther},
+	[]models.EGOTEventType{models.EventFirstWin, models.EventReachedThree, models.EventCompletedEGOT},
+	[]models.WebhookEventType{models.EventAwardWon, models.EventNominationAdded, models.EventEGOTProgressChanged},
+	[]models.WebhookDeliveryStatus{models.DeliveryPending, models.DeliveryDelivered, models.DeliveryFailed},
+}
+
+// pageParams are the query parameters of every paginated endpoint
+var pageParams = []*openapi.Param{
+	openapi.Query("limit", fmt.Sprintf("Page size, default %d, at most %d", pagination.DefaultLimit, pagination.MaxLimit), openapi.Integer()),
+	openapi.Query("cursor", "next_cursor from the previous page", openapi.String()),
+}
+
+// celebrityFilterParams are the filters read by parseCelebrityFilter
+var celebrityFilterParams = []*openapi.Param{
+	openapi.Query("min_wins", "Minimum number of distinct EGOT awards won", openapi.Between(0, 4)),
+	openapi.Query("max_wins", "Maximum number of distinct EGOT awards won", openapi.Between(0, 4)),
+	openapi.List("missing", "Award types not won", openapi.Enum(models.AllAwardTypes...)),
+	openapi.Query("needs", "Has won every award except this one", openapi.Enum(models.AllAwardTypes...)),
+	openapi.Query("won_from", "Won an award in or after this year", openapi.Integer()),
+	openapi.Query("won_to", "Won an award in or before this year", openapi.Integer()),
+	openapi.Query("status", "Whether a date of death is known", openapi.Enum(models.LifeStatusLiving, models.LifeStatusDeceased)),
+	openapi.Query("has_awards", "Has any award or nomination on record", openapi.Boolean()),
+}
+
+func params(groups ...[]*openapi.Param) []*openapi.Param {
+	var all []*openapi.Param
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	return all
+}
+
+var (
+	celebrityIDParam = openapi.Path("id", "Celebrity ID", openapi.UUID())
+	watchlistIDParam = openapi.Path("id", "Watchlist ID", openapi.UUID())
+)
+
+// Operations documents every route by its ServeMux pattern. JSON API
+// routes are relative to the prefix they are mounted under, such as /api/v1.
+var Operations = map[string]openapi.Operation{
+	"GET /health": {
+		Summary:  "Health check",
+		Tag:      "meta",
+		Response: map[string]string{},
+	},
+	"GET /openapi.json": {
+		Summary:  "This OpenAPI document",
+		Tag:      "meta",
+		Response: map[string]any{},
+	},
+
+	"GET /celebrities": {
+		Summary: "Browse celebrities with filters, sorting and facet counts",
+		Tag:     "celebrities",
+		Params: params(celebrityFilterParams, []*openapi.Param{
+			openapi.Query("sort", "Sort order", openapi.Enum(models.SortByName, models.SortByRecentWin, models.SortByTotalWins, models.SortByUpdated)),
+		}, pageParams),
+		Response: v1.BrowseResult{},
+	},
+	"GET /celebrity/search": {
+		Summary:     "Search for a celebrity",
+		Description: "Returns the stored celebrity, scraping Wikidata if they are not tracked yet or their awards are stale.",
+		Tag:         "celebrities",
+		Params:      []*openapi.Param{openapi.RequiredQuery("q", "Name to search for", openapi.String())},
+		Response:    v1.CelebrityWithAwards{},
+	},
+	"GET /celebrity/autocomplete": {
+		Summary:  "Autocomplete suggestions",
+		Tag:      "celebrities",
+		Params:   []*openapi.Param{openapi.Query("q", "Name prefix", openapi.String())},
+		Response: []v1.Celebrity{},
+	},
+	"GET /celebrity/close-to-egot": {
+		Summary:  "Celebrities with 3 of the 4 awards",
+		Tag:      "celebrities",
+		Params:   pageParams,
+		Response: pagination.Page[v1.CelebrityWithProgress]{},
+	},
+	"GET /celebrity/egot-winners": {
+		Summary:  "Celebrities with all 4 awards",
+		Tag:      "celebrities",
+		Params:   pageParams,
+		Response: pagination.Page[v1.CelebrityWithProgress]{},
+	},
+	"GET /celebrity/no-awards": {
+		Summary:  "Celebrities with no awards",
+		Tag:      "celebrities",
+		Params:   pageParams,
+		Response: pagination.Page[v1.Celebrity]{},
+	},
+	"GET /egot-watch": {
+		Summary:  "Upcoming nominations that would complete an EGOT or reach 3 of 4",
+		Tag:      "celebrities",
+		Response: []v1.UpcomingCeremony{},
+	},
+	"GET /celebrity/{id}/aliases": {
+		Summary:  "List a celebrity's alternate names",
+		Tag:      "celebrities",
+		Params:   []*openapi.Param{celebrityIDParam},
+		Response: []v1.Alias{},
+	},
+	"POST /celebrity/{id}/aliases": {
+		Summary:     "Add an alternate name",
+		Description: "Requires an admin API key.",
+		Tag:         "celebrities",
+		Auth:        true,
+		Params:      []*openapi.Param{celebrityIDParam},
+		Body:        addAliasRequest{},
+		Status:      http.StatusCreated,
+		Response:    v1.Alias{},
+	},
+	"GET /celebrity/{id}/collaborators": {
+		Summary:  "People who won awards for the same works",
+		Tag:      "collaborators",
+		Params:   []*openapi.Param{celebrityIDParam},
+		Response: []v1.Collaborator{},
+	},
+	"GET /path": {
+		Summary: "Shortest chain of shared award-winning works between two people",
+		Tag:     "collaborators",
+		Params: []*openapi.Param{
+			openapi.RequiredQuery("from", "Celebrity ID", openapi.UUID()),
+			openapi.RequiredQuery("to", "Celebrity ID", openapi.UUID()),
+		},
+		Response: v1.CollaborationPath{},
+	},
+	"GET /works/{id}": {
+		Summary:  "A work with every tracked person's awards for it",
+		Tag:      "works",
+		Params:   []*openapi.Param{openapi.Path("id", "Work UUID or Wikidata ID", openapi.String())},
+		Response: v1.WorkWithCredits{},
+	},
+
+	"GET /watchlists": {
+		Summary:  "List your watchlists",
+		Tag:      "watchlists",
+		Auth:     true,
+		Response: []v1.Watchlist{},
+	},
+	"POST /watchlists": {
+		Summary:     "Create a watchlist",
+		Description: "The response includes the webhook secret, which is not shown again.",
+		Tag:         "watchlists",
+		Auth:        true,
+		Body:        createWatchlistRequest{},
+		Status:      http.StatusCreated,
+		Response:    v1.CreatedWatchlist{},
+	},
+	"GET /watchlists/{id}": {
+		Summary:  "A watchlist with its celebrities",
+		Tag:      "watchlists",
+		Auth:     true,
+		Params:   []*openapi.Param{watchlistIDParam},
+		Response: v1.WatchlistWithCelebrities{},
+	},
+	"DELETE /watchlists/{id}": {
+		Summary: "Delete a watchlist",
+		Tag:     "watchlists",
+		Auth:    true,
+		Params:  []*openapi.Param{watchlistIDParam},
+		Status:  http.StatusNoContent,
+	},
+	"POST /watchlists/{id}/calendar-token": {
+		Summary:     "Issue a calendar token",
+		Description: "Replaces any previous token. The token only reads the watchlist's calendar feed and is not shown again.",
+		Tag:         "watchlists",
+		Auth:        true,
+		Params:      []*openapi.Param{watchlistIDParam},
+		Status:      http.StatusCreated,
+		Response:    v1.CalendarToken{},
+	},
+	"PUT /watchlists/{id}/celebrities/{celebrityId}": {
+		Summary: "Watch a celebrity",
+		Tag:     "watchlists",
+		Auth:    true,
+		Params:  []*openapi.Param{watchlistIDParam, openapi.Path("celebrityId", "Celebrity ID", openapi.UUID())},
+		Status:  http.StatusNoContent,
+	},
+	"DELETE /watchlists/{id}/celebrities/{celebrityId}": {
+		Summary: "Stop watching a celebrity",
+		Tag:     "watchlists",
+		Auth:    true,
+		Params:  []*openapi.Param{watchlistIDParam, openapi.Path("celebrityId", "Celebrity ID", openapi.UUID())},
+		Status:  http.StatusNoContent,
+	},
+	"GET /watchlists/{id}/deliveries": {
+		Summary:  "Webhook delivery log with every attempt",
+		Tag:      "watchlists",
+		Auth:     true,
+		Params:   params([]*openapi.Param{watchlistIDParam}, pageParams),
+		Response: pagination.Page[v1.Delivery]{},
+	},
+
+	"GET /oscar-race/years": {
+		Summary:  "Tracked Oscar ceremony years, most recent first",
+		Tag:      "oscar-race",
+		Params:   pageParams,
+		Response: pagination.Page[int]{},
+	},
+	"GET /oscar-race/{year}": {
+		Summary:  "An Oscar ceremony with its categories and nominees",
+		Tag:      "oscar-race",
+		Params:   []*openapi.Param{openapi.Path("year", "Ceremony year", openapi.Between(1929, 2100))},
+		Response: v1.OscarCeremony{},
+	},
+	"PUT /oscar-race/{year}/category/{categoryId}/winner/{nomineeId}": {
+		Summary: "Mark a nominee as the winner of their category",
+		Tag:     "oscar-race",
+		Params: []*openapi.Param{
+			openapi.Path("year", "Ceremony year", openapi.Integer()),
+			openapi.Path("categoryId", "Category ID", openapi.UUID()),
+			openapi.Path("nomineeId", "Nominee ID", openapi.UUID()),
+		},
+		Response: map[string]string{},
+	},
+
+	"GET /stats": {
+		Summary:  "Counts by award type, decade, EGOT progress and sub-body",
+		Tag:      "stats",
+		Response: v1.Stats{},
+	},
+	"GET /stats/egot-timeline": {
+		Summary:  "EGOT winners ordered by completion date and by fastest span",
+		Tag:      "stats",
+		Response: v1.EGOTTimelineStats{},
+	},
+	"GET /leaderboards/{metric}": {
+		Summary: "Celebrities ranked by a metric, with ties sharing a rank",
+		Tag:     "stats",
+		Params: params([]*openapi.Param{
+			openapi.Path("metric", "Ranking metric", openapi.Enum(models.AllLeaderboardMetrics...)),
+		}, celebrityFilterParams, pageParams),
+		Response: pagination.Page[v1.LeaderboardEntry]{},
+	},
+	"GET /events": {
+		Summary: "EGOT milestones, newest first",
+		Tag:     "events",
+		Params: params([]*openapi.Param{
+			openapi.List("type", "Event types", openapi.Enum(models.EventFirstWin, models.EventReachedThree, models.EventCompletedEGOT)),
+			openapi.Query("celebrity_id", "Only this celebrity's milestones", openapi.UUID()),
+			openapi.Query("exclude_initial", "Skip milestones found on a celebrity's first import", openapi.Boolean()),
+		}, pageParams),
+		Response: pagination.Page[v1.EGOTEvent]{},
+	},
+
+	"GET /feeds/awards.atom": {
+		Summary:     "Atom feed of recently added or changed awards",
+		Tag:         "feeds",
+		ContentType: "application/atom+xml",
+	},
+	"GET /feeds/awards.rss": {
+		Summary:     "RSS feed of recently added or changed awards",
+		Tag:         "feeds",
+		ContentType: "application/rss+xml",
+	},
+	"GET /feeds/egot.atom": {
+		Summary:     "Atom feed of newly completed EGOTs",
+		Tag:         "feeds",
+		ContentType: "application/atom+xml",
+	},
+	"GET /feeds/egot.rss": {
+		Summary:     "RSS feed of newly completed EGOTs",
+		Tag:         "feeds",
+		ContentType: "application/rss+xml",
+	},
+	"GET /feeds/celebrity/{file}": {
+		Summary:     "Atom or RSS feed of one celebrity's awards",
+		Tag:         "feeds",
+		Params:      []*openapi.Param{openapi.Path("file", "Celebrity slug followed by .atom or .rss", openapi.String())},
+		ContentType: "application/atom+xml",
+	},
+	"GET /calendar/ceremonies.ics": {
+		Summary: "iCalendar feed of upcoming ceremonies with tracked nominees",
+		Tag:     "feeds",
+		Params: []*openapi.Param{
+			openapi.Query("close_to_egot", "Only ceremonies where someone with 3 of the 4 awards is nominated", openapi.Boolean()),
+			openapi.Query("token", "Calendar token of a watchlist; only ceremonies where someone on it is nominated", openapi.String()),
+		},
+		ContentType: "text/calendar",
+	},
+
+	"GET /graphql": {
+		Summary: "Run a GraphQL query",
+		Tag:     "graphql",
+		Params: []*openapi.Param{
+			openapi.RequiredQuery("query", "GraphQL query", openapi.String()),
+			openapi.Query("variables", "JSON object of variables", openapi.String()),
+			openapi.Query("operationName", "Operation to run", openapi.String()),
+		},
+		Response: graphql.Result{},
+	},
+	"POST /graphql": {
+		Summary:  "Run a GraphQL query",
+		Tag:      "graphql",
+		Body:     gql.Request{},
+		Response: graphql.Result{},
+	},
+}
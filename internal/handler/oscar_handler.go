@@ -1,11 +1,11 @@
 package handler
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/pagination"
 	"egot-tracker/internal/service"
 	"egot-tracker/pkg/response"
 
@@ -20,45 +20,43 @@ func NewOscarHandler(service *service.OscarService) *OscarHandler {
 	return &OscarHandler{service: service}
 }
 
-// GetCeremony handles GET /api/oscar-race/{year}
+// GetCeremony handles GET /api/v1/oscar-race/{year}
 func (h *OscarHandler) GetCeremony(w http.ResponseWriter, r *http.Request) {
 	// Extract year from path
 	yearStr := r.PathValue("year")
 	year, err := strconv.Atoi(yearStr)
 	if err != nil || year < 1929 || year > 2100 {
-		response.Error(w, http.StatusBadRequest, "invalid year")
+		writeError(w, r, service.InvalidInput("year", "invalid year"))
 		return
 	}
 
 	ceremony, err := h.service.GetCeremony(r.Context(), year)
-	if errors.Is(err, service.ErrCeremonyNotFound) {
-		response.Error(w, http.StatusNotFound, "ceremony not found")
-		return
-	}
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, err)
 		return
 	}
 
-	response.JSON(w, http.StatusOK, ceremony)
+	response.JSON(w, http.StatusOK, v1.NewOscarCeremony(*ceremony))
 }
 
-// GetYears handles GET /api/oscar-race/years
+// GetYears handles GET /api/v1/oscar-race/years
 func (h *OscarHandler) GetYears(w http.ResponseWriter, r *http.Request) {
-	years, err := h.service.GetAllYears(r.Context())
+	page, err := pagination.ParseParams(r, service.CeremonyYearsCursors)
 	if err != nil {
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
 		return
 	}
 
-	if years == nil {
-		years = []int{}
+	years, err := h.service.GetAllYears(r.Context(), page)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
 	response.JSON(w, http.StatusOK, years)
 }
 
-// SetWinner handles PUT /api/oscar-race/{year}/category/{categoryId}/winner/{nomineeId}
+// SetWinner handles PUT /api/v1/oscar-race/{year}/category/{categoryId}/winner/{nomineeId}
 func (h *OscarHandler) SetWinner(w http.ResponseWriter, r *http.Request) {
 	// Extract nomineeId from path
 	nomineeIdStr := r.PathValue("nomineeId")
@@ -66,23 +64,19 @@ func (h *OscarHandler) SetWinner(w http.ResponseWriter, r *http.Request) {
 	// Parse UUID
 	var nomineeID pgtype.UUID
 	if err := nomineeID.Scan(nomineeIdStr); err != nil {
-		response.Error(w, http.StatusBadRequest, "invalid nominee ID")
+		writeError(w, r, service.InvalidInput("nomineeId", "invalid nominee ID"))
 		return
 	}
 
 	// Validate UUID is set
 	if !nomineeID.Valid {
-		response.Error(w, http.StatusBadRequest, "nominee ID is required")
+		writeError(w, r, service.InvalidInput("nomineeId", "nominee ID is required"))
 		return
 	}
 
 	// Set the winner
 	if err := h.service.SetWinner(r.Context(), nomineeID); err != nil {
-		if strings.Contains(err.Error(), "no rows") {
-			response.Error(w, http.StatusNotFound, "nominee not found")
-			return
-		}
-		response.Error(w, http.StatusInternalServerError, "internal server error")
+		writeError(w, r, err)
 		return
 	}
 
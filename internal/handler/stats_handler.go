@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+)
+
+type StatsHandler struct {
+	service *service.StatsService
+}
+
+func NewStatsHandler(service *service.StatsService) *StatsHandler {
+	return &StatsHandler{service: service}
+}
+
+// GetStats handles GET /api/v1/stats
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetStats(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.NewStats(*stats))
+}
+
+// EGOTTimeline handles GET /api/v1/stats/egot-timeline
+func (h *StatsHandler) EGOTTimeline(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetEGOTTimelines(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.NewEGOTTimelineStats(stats.ByCompletion, stats.BySpan))
+}
+
+// Leaderboard handles GET /api/v1/leaderboards/{metric}. It accepts the same
+// filters and pagination as GET /api/v1/celebrities.
+func (h *StatsHandler) Leaderboard(w http.ResponseWriter, r *http.Request) {
+	metric := models.LeaderboardMetric(r.PathValue("metric"))
+
+	filter, err := parseCelebrityFilter(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := pagination.ParseParams(r, service.LeaderboardCursors(metric))
+	if err != nil {
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
+		return
+	}
+
+	results, err := h.service.GetLeaderboard(r.Context(), metric, filter, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pagination.Map(results, v1.NewLeaderboardEntry))
+}
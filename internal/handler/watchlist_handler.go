@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// WatchlistHandler serves the watchlist endpoints. Every handler must be
+// wrapped in RequireAPIKey; watchlists are only visible to the key owning them.
+type WatchlistHandler struct {
+	service *service.WatchlistService
+}
+
+func NewWatchlistHandler(service *service.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{service: service}
+}
+
+// createWatchlistRequest is the body of POST /api/v1/watchlists
+type createWatchlistRequest struct {
+	Name       string `json:"name"`
+	WebhookURL string `json:"webhook_url"`
+}
+
+// Create handles POST /api/v1/watchlists
+func (h *WatchlistHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req createWatchlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, service.InvalidInput("body", "invalid request body"))
+		return
+	}
+
+	watchlist, secret, err := h.service.CreateWatchlist(r.Context(), apiKeyFrom(r).ID, req.Name, req.WebhookURL)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, v1.NewCreatedWatchlist(*watchlist, secret))
+}
+
+// List handles GET /api/v1/watchlists
+func (h *WatchlistHandler) List(w http.ResponseWriter, r *http.Request) {
+	watchlists, err := h.service.GetWatchlists(r.Context(), apiKeyFrom(r).ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.List(watchlists, v1.NewWatchlist))
+}
+
+// Get handles GET /api/v1/watchlists/{id}
+func (h *WatchlistHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	watchlist, err := h.service.GetWatchlist(r.Context(), apiKeyFrom(r).ID, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.NewWatchlistWithCelebrities(*watchlist))
+}
+
+// Delete handles DELETE /api/v1/watchlists/{id}
+func (h *WatchlistHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteWatchlist(r.Context(), apiKeyFrom(r).ID, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CalendarToken handles POST /api/v1/watchlists/{id}/calendar-token
+func (h *WatchlistHandler) CalendarToken(w http.ResponseWriter, r *http.Request) {
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	token, err := h.service.IssueCalendarToken(r.Context(), apiKeyFrom(r).ID, id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, v1.CalendarToken{CalendarToken: token})
+}
+
+// AddCelebrity handles PUT /api/v1/watchlists/{id}/celebrities/{celebrityId}
+func (h *WatchlistHandler) AddCelebrity(w http.ResponseWriter, r *http.Request) {
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	var celebrityID pgtype.UUID
+	if err := celebrityID.Scan(r.PathValue("celebrityId")); err != nil || !celebrityID.Valid {
+		writeError(w, r, service.InvalidInput("celebrityId", "invalid celebrity ID"))
+		return
+	}
+
+	err := h.service.AddCelebrity(r.Context(), apiKeyFrom(r).ID, id, celebrityID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveCelebrity handles DELETE /api/v1/watchlists/{id}/celebrities/{celebrityId}
+func (h *WatchlistHandler) RemoveCelebrity(w http.ResponseWriter, r *http.Request) {
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	var celebrityID pgtype.UUID
+	if err := celebrityID.Scan(r.PathValue("celebrityId")); err != nil || !celebrityID.Valid {
+		writeError(w, r, service.InvalidInput("celebrityId", "invalid celebrity ID"))
+		return
+	}
+
+	err := h.service.RemoveCelebrity(r.Context(), apiKeyFrom(r).ID, id, celebrityID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Deliveries handles GET /api/v1/watchlists/{id}/deliveries
+func (h *WatchlistHandler) Deliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := watchlistID(w, r)
+	if !ok {
+		return
+	}
+
+	page, err := pagination.ParseParams(r, service.DeliveryCursors)
+	if err != nil {
+		writeError(w, r, service.InvalidInput("cursor", "invalid cursor"))
+		return
+	}
+
+	deliveries, err := h.service.GetDeliveries(r.Context(), apiKeyFrom(r).ID, id, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, pagination.Map(deliveries, v1.NewDelivery))
+}
+
+// watchlistID parses the {id} path value, writing a 400 response if invalid
+func watchlistID(w http.ResponseWriter, r *http.Request) (pgtype.UUID, bool) {
+	var id pgtype.UUID
+	if err := id.Scan(r.PathValue("id")); err != nil || !id.Valid {
+		writeError(w, r, service.InvalidInput("id", "invalid watchlist ID"))
+		return id, false
+	}
+	return id, true
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	v1 "egot-tracker/internal/api/v1"
+	"egot-tracker/internal/service"
+	"egot-tracker/pkg/response"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WorkHandler struct {
+	service *service.WorkService
+}
+
+func NewWorkHandler(service *service.WorkService) *WorkHandler {
+	return &WorkHandler{service: service}
+}
+
+// GetWork handles GET /api/v1/works/{id}, where id is the work's UUID or its
+// Wikidata ID
+func (h *WorkHandler) GetWork(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+
+	var workID pgtype.UUID
+	var wikidataID string
+	if service.IsWikidataID(id) {
+		wikidataID = id
+	} else if err := workID.Scan(id); err != nil || !workID.Valid {
+		writeError(w, r, service.InvalidInput("id", "invalid work ID"))
+		return
+	}
+
+	work, err := h.service.GetWork(r.Context(), workID, wikidataID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response.JSON(w, http.StatusOK, v1.NewWorkWithCredits(*work))
+}
@@ -0,0 +1,102 @@
+// Package ical renders iCalendar (RFC 5545) feeds of all-day events
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// Calendar is a published calendar of events
+type Calendar struct {
+	Name        string
+	Description string
+	Events      []Event
+}
+
+// Event is an all-day event
+type Event struct {
+	UID         string // permanent, globally unique identifier
+	Summary     string
+	Description string
+	URL         string
+	Date        time.Time // only the date is used
+}
+
+// Write writes c as an iCalendar document, stamped with the current time
+func Write(w io.Writer, c Calendar) error {
+	bw := bufio.NewWriter(w)
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+
+	writeLine(bw, "BEGIN:VCALENDAR")
+	writeLine(bw, "VERSION:2.0")
+	writeLine(bw, "PRODID:-//EGOT Tracker//Ceremonies//EN")
+	writeLine(bw, "CALSCALE:GREGORIAN")
+	writeLine(bw, "METHOD:PUBLISH")
+	writeLine(bw, "X-WR-CALNAME:"+escape(c.Name))
+	if c.Description != "" {
+		writeLine(bw, "X-WR-CALDESC:"+escape(c.Description))
+	}
+	for _, e := range c.Events {
+		writeLine(bw, "BEGIN:VEVENT")
+		writeLine(bw, "UID:"+escape(e.UID))
+		writeLine(bw, "DTSTAMP:"+stamp)
+		writeLine(bw, "DTSTART;VALUE=DATE:"+e.Date.Format("20060102"))
+		writeLine(bw, "DTEND;VALUE=DATE:"+e.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeLine(bw, "SUMMARY:"+escape(e.Summary))
+		if e.Description != "" {
+			writeLine(bw, "DESCRIPTION:"+escape(e.Description))
+		}
+		if e.URL != "" {
+			writeLine(bw, "URL:"+e.URL)
+		}
+		writeLine(bw, "TRANSP:TRANSPARENT")
+		writeLine(bw, "END:VEVENT")
+	}
+	writeLine(bw, "END:VCALENDAR")
+
+	return bw.Flush()
+}
+
+// maxLineOctets is the longest a content line may be before folding
+const maxLineOctets = 75
+
+// writeLine writes a content line terminated by CRLF, folding it so no line
+// exceeds 75 octets without splitting a UTF-8 character
+func writeLine(w *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
+
+// textEscaper escapes TEXT values. Every line break, including a lone CR,
+// becomes \n, since a raw CR would end the content line.
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// escape escapes a TEXT value
+func escape(s string) string {
+	return textEscaper.Replace(s)
+}
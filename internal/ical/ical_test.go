@@ -0,0 +1,87 @@
+package ical
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWriteLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		lines []int // octets in each physical line, without CRLF
+	}{
+		{"short", "SUMMARY:Oscars", []int{14}},
+		{"exactly 75 octets", strings.Repeat("a", 75), []int{75}},
+		{"76 octets", strings.Repeat("a", 76), []int{75, 2}},
+		{"continuation lines hold 74 octets", strings.Repeat("a", 75+74+1), []int{75, 75, 2}},
+		// é is 2 octets; octet 75 is inside the 38th, which moves to the
+		// next line
+		{"2-octet runes", strings.Repeat("é", 40), []int{74, 7}},
+		// 🏆 is 4 octets; the 19th spans octets 72 to 75
+		{"4-octet runes", strings.Repeat("🏆", 30), []int{72, 49}},
+		{"rune ending at the limit", "a" + strings.Repeat("é", 37) + "é", []int{75, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			w := bufio.NewWriter(&b)
+			writeLine(w, tt.line)
+			w.Flush()
+
+			out := b.String()
+			if !strings.HasSuffix(out, "\r\n") {
+				t.Fatalf("line %q does not end with CRLF", out)
+			}
+			physical := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+
+			lengths := make([]int, len(physical))
+			for i, p := range physical {
+				lengths[i] = len(p)
+				if len(p) > maxLineOctets {
+					t.Errorf("line %d is %d octets", i+1, len(p))
+				}
+				if !utf8.ValidString(p) {
+					t.Errorf("line %d splits a UTF-8 character: %q", i+1, p)
+				}
+				if i > 0 && !strings.HasPrefix(p, " ") {
+					t.Errorf("continuation line %d does not start with a space", i+1)
+				}
+			}
+			if !slices.Equal(lengths, tt.lines) {
+				t.Errorf("line lengths = %v, want %v", lengths, tt.lines)
+			}
+
+			if unfolded := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", ""); unfolded != tt.line {
+				t.Errorf("unfolded line = %q, want %q", unfolded, tt.line)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"96th Academy Awards", "96th Academy Awards"},
+		{`back\slash`, `back\\slash`},
+		{"a;b,c", `a\;b\,c`},
+		{"one\ntwo", `one\ntwo`},
+		{"one\r\ntwo", `one\ntwo`},
+		{"one\rtwo", `one\ntwo`},
+		{"one\n\rtwo", `one\n\ntwo`},
+		{"trailing\r", `trailing\n`},
+		{"Beyoncé", "Beyoncé"},
+	}
+
+	for _, tt := range tests {
+		if got := escape(tt.in); got != tt.want {
+			t.Errorf("escape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
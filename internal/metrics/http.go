@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// unmatchedRoute labels requests that matched no registered route, so
+// scanners probing random paths cannot create unbounded series
+const unmatchedRoute = "unmatched"
+
+var httpRequestDuration = NewHistogram(
+	"egot_http_request_duration_seconds",
+	"Time taken to serve HTTP requests, by route pattern and status.",
+	DefaultBuckets,
+	"route", "status",
+)
+
+type routeKey struct{}
+
+// routeLabel is filled in by SetRoute once the request has been routed
+type routeLabel struct {
+	pattern string
+}
+
+// SetRoute records the pattern of the route serving the request in ctx, for
+// the request duration metric. Routers call it before running the handler.
+func SetRoute(ctx context.Context, pattern string) {
+	if label, ok := ctx.Value(routeKey{}).(*routeLabel); ok {
+		label.pattern = pattern
+	}
+}
+
+// Middleware records the duration and status of every request, labelled by
+// the route pattern passed to SetRoute
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		label := &routeLabel{pattern: unmatchedRoute}
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+
+		start := time.Now()
+		next.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), routeKey{}, label)))
+		httpRequestDuration.Observe(time.Since(start).Seconds(), label.pattern, strconv.Itoa(sw.status))
+	})
+}
+
+// statusWriter records the status of a response
+type statusWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	if !sw.wroteHeader {
+		sw.status = status
+		sw.wroteHeader = true
+	}
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+func (sw *statusWriter) Write(b []byte) (int, error) {
+	sw.wroteHeader = true
+	return sw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (sw *statusWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
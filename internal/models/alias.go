@@ -0,0 +1,21 @@
+package models
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// AliasSource identifies how an alias was added
+type AliasSource string
+
+const (
+	AliasSourceWikidata AliasSource = "wikidata"
+	AliasSourceManual   AliasSource = "manual"
+)
+
+// CelebrityAlias is an alternate name a celebrity can be found by,
+// such as a birth name or stage name
+type CelebrityAlias struct {
+	ID          pgtype.UUID        `json:"id" db:"id"`
+	CelebrityID pgtype.UUID        `json:"celebrity_id" db:"celebrity_id"`
+	Alias       string             `json:"alias" db:"alias"`
+	Source      AliasSource        `json:"source" db:"source"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at" db:"created_at"`
+}
@@ -1,6 +1,12 @@
 package models
 
-import "github.com/jackc/pgx/v5/pgtype"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
 
 type AwardType string
 
@@ -11,6 +17,15 @@ const (
 	AwardTypeTony  AwardType = "Tony"
 )
 
+// AwardSource identifies the system an award row was loaded from
+type AwardSource string
+
+const (
+	AwardSourceSeed     AwardSource = "seed"
+	AwardSourceWikidata AwardSource = "wikidata"
+	AwardSourceManual   AwardSource = "manual"
+)
+
 type Award struct {
 	ID           pgtype.UUID `json:"id" db:"id"`
 	CelebrityID  pgtype.UUID `json:"celebrity_id" db:"celebrity_id"`
@@ -21,4 +36,80 @@ type Award struct {
 	IsWinner     bool        `json:"is_winner" db:"is_winner"`
 	CeremonyDate pgtype.Date `json:"ceremony_date,omitempty" db:"ceremony_date"`
 	IsUpcoming   bool        `json:"is_upcoming" db:"is_upcoming"`
+	WorkID       pgtype.UUID `json:"work_id" db:"work_id"`
+
+	// Provenance, exposed through the sources block rather than on the award itself
+	Source              AwardSource        `json:"-" db:"source"`
+	WikidataStatementID pgtype.Text        `json:"-" db:"wikidata_statement_id"`
+	WikidataAwardID     pgtype.Text        `json:"-" db:"wikidata_award_id"`
+	FetchedAt           pgtype.Timestamptz `json:"-" db:"fetched_at"`
+	ScraperVersion      pgtype.Text        `json:"-" db:"scraper_version"`
+	WikidataWorkID      pgtype.Text        `json:"-" db:"wikidata_work_id"`
+
+	// When the row was added and when its outcome last changed, for feeds
+	CreatedAt pgtype.Timestamptz `json:"-" db:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"-" db:"updated_at"`
+}
+
+// AwardWithCelebrity is an award together with its recipient
+type AwardWithCelebrity struct {
+	Award
+	Celebrity Celebrity `json:"celebrity"`
+}
+
+// NaturalKey identifies an award independent of its row ID. It mirrors the
+// unique index on awards (celebrity_id, type, category, year, work).
+func (a *Award) NaturalKey() string {
+	return fmt.Sprintf("%x|%s|%s|%d|%s", a.CelebrityID.Bytes, a.Type, a.Category, a.Year, a.Work)
+}
+
+// AwardSourceInfo describes where a single award row came from
+type AwardSourceInfo struct {
+	AwardID             pgtype.UUID `json:"award_id"`
+	Source              AwardSource `json:"source"`
+	WikidataStatementID *string     `json:"wikidata_statement_id,omitempty"`
+	WikidataAwardID     *string     `json:"wikidata_award_id,omitempty"`
+	WikidataWorkID      *string     `json:"wikidata_work_id,omitempty"`
+	URL                 *string     `json:"url,omitempty"`
+	FetchedAt           *time.Time  `json:"fetched_at,omitempty"`
+	ScraperVersion      *string     `json:"scraper_version,omitempty"`
+}
+
+// SourceInfo returns the provenance of the award, linking to the Wikidata
+// statement it was read from when one is known
+func (a *Award) SourceInfo() AwardSourceInfo {
+	info := AwardSourceInfo{
+		AwardID: a.ID,
+		Source:  a.Source,
+	}
+	if a.WikidataStatementID.Valid {
+		statementID := a.WikidataStatementID.String
+		info.WikidataStatementID = &statementID
+		url := WikidataStatementURL(statementID)
+		info.URL = &url
+	}
+	if a.WikidataAwardID.Valid {
+		awardID := a.WikidataAwardID.String
+		info.WikidataAwardID = &awardID
+	}
+	if a.WikidataWorkID.Valid {
+		workID := a.WikidataWorkID.String
+		info.WikidataWorkID = &workID
+	}
+	if a.FetchedAt.Valid {
+		fetchedAt := a.FetchedAt.Time
+		info.FetchedAt = &fetchedAt
+	}
+	if a.ScraperVersion.Valid {
+		version := a.ScraperVersion.String
+		info.ScraperVersion = &version
+	}
+	return info
+}
+
+// WikidataStatementURL builds a link to a statement on its entity page.
+// Statement IDs have the form "Q42$F078E5B3-...", where the prefix is the entity.
+func WikidataStatementURL(statementID string) string {
+	entity, _, _ := strings.Cut(statementID, "$")
+	return "https://www.wikidata.org/wiki/" + entity + "#" + statementID
 }
@@ -0,0 +1,50 @@
+package models
+
+// CelebritySort is an ordering for the celebrity browse endpoint
+type CelebritySort string
+
+const (
+	SortByName      CelebritySort = "name"       // alphabetical
+	SortByRecentWin CelebritySort = "recent_win" // most recent win first
+	SortByTotalWins CelebritySort = "total_wins" // most wins first
+	SortByUpdated   CelebritySort = "updated"    // least recently updated first
+)
+
+// LifeStatus filters celebrities by whether a date of death is known
+type LifeStatus string
+
+const (
+	LifeStatusLiving   LifeStatus = "living"
+	LifeStatusDeceased LifeStatus = "deceased"
+)
+
+// AllAwardTypes lists the EGOT award types in EGOT order
+var AllAwardTypes = []AwardType{AwardTypeEmmy, AwardTypeGrammy, AwardTypeOscar, AwardTypeTony}
+
+// CelebrityFilter selects celebrities for the browse endpoint. Nil and
+// empty fields do not filter.
+type CelebrityFilter struct {
+	MinWins   *int        // minimum number of distinct EGOT awards won
+	MaxWins   *int        // maximum number of distinct EGOT awards won
+	Missing   []AwardType // award types the celebrity has not won
+	WonFrom   *int        // won any EGOT award in or after this year
+	WonTo     *int        // won any EGOT award in or before this year
+	Status    LifeStatus
+	HasAwards *bool // has any award row at all, including nominations
+	Sort      CelebritySort
+}
+
+// CelebrityBrowseItem is a celebrity with the statistics browse sorts on
+type CelebrityBrowseItem struct {
+	CelebrityWithEGOTProgress
+	TotalWins     int  `json:"total_wins"`
+	LatestWinYear *int `json:"latest_win_year"`
+}
+
+// CelebrityFacets counts the celebrities matching a filter along each
+// browse dimension
+type CelebrityFacets struct {
+	WinCount map[int]int64        `json:"win_count"`
+	Missing  map[AwardType]int64  `json:"missing"`
+	Status   map[LifeStatus]int64 `json:"status"`
+}
@@ -13,11 +13,32 @@ type Celebrity struct {
 	PhotoURL    pgtype.Text      `json:"photo_url" db:"photo_url"`
 	Summary     pgtype.Text      `json:"summary" db:"summary"`
 	LastUpdated pgtype.Timestamp `json:"last_updated" db:"last_updated"`
+	DeathDate   pgtype.Date      `json:"death_date" db:"death_date"`
 }
 
 type CelebrityWithAwards struct {
 	Celebrity
-	Awards []Award `json:"awards"`
+	Awards   []Award           `json:"awards"`
+	Sources  []AwardSourceInfo `json:"sources"`
+	Timeline EGOTTimeline      `json:"egot_timeline"`
+}
+
+// NewCelebrityWithAwards pairs a celebrity with their awards, the
+// provenance of each award, and their EGOT timeline
+func NewCelebrityWithAwards(celebrity Celebrity, awards []Award) *CelebrityWithAwards {
+	if awards == nil {
+		awards = []Award{}
+	}
+	sources := make([]AwardSourceInfo, 0, len(awards))
+	for i := range awards {
+		sources = append(sources, awards[i].SourceInfo())
+	}
+	return &CelebrityWithAwards{
+		Celebrity: celebrity,
+		Awards:    awards,
+		Sources:   sources,
+		Timeline:  BuildEGOTTimeline(awards),
+	}
 }
 
 // CelebrityWithEGOTProgress represents a celebrity with their EGOT win count
@@ -47,3 +68,8 @@ func (c *Celebrity) GetSummary() *string {
 	}
 	return nil
 }
+
+// IsDeceased reports whether a date of death is known for the celebrity
+func (c *Celebrity) IsDeceased() bool {
+	return c.DeathDate.Valid
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Ceremony is an upcoming award ceremony with an announced date
+type Ceremony struct {
+	Type        AwardType         `json:"type"`
+	Year        int               `json:"year"`
+	Name        string            `json:"name"` // empty unless the Oscar race names it
+	Date        pgtype.Date       `json:"date"`
+	InOscarRace bool              `json:"in_oscar_race"`
+	Nominees    []CeremonyNominee `json:"nominees"`
+}
+
+// CeremonyNominee is a tracked celebrity nominated at a ceremony
+type CeremonyNominee struct {
+	Celebrity CelebrityWithEGOTProgress `json:"celebrity"`
+	Category  string                    `json:"category"`
+	Work      string                    `json:"work"`
+}
+
+// CompletesEGOT reports whether winning would give the nominee all four awards
+func (n CeremonyNominee) CompletesEGOT(awardType AwardType) bool {
+	return n.Celebrity.EGOTWinCount == 3 && !slices.Contains(n.Celebrity.WonAwards, string(awardType))
+}
+
+// CeremonyFilter narrows a ceremony calendar to ceremonies where chosen
+// celebrities are nominated. The zero value includes every ceremony.
+type CeremonyFilter struct {
+	CloseToEGOT bool          // celebrities with 3 of the 4 awards
+	Celebrities []pgtype.UUID // e.g. the celebrities on a watchlist
+}
+
+// IsEmpty reports whether the filter includes every ceremony
+func (f CeremonyFilter) IsEmpty() bool {
+	return !f.CloseToEGOT && f.Celebrities == nil
+}
+
+// Matches reports whether a nominee passes the filter
+func (f CeremonyFilter) Matches(n CeremonyNominee) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if f.CloseToEGOT && n.Celebrity.EGOTWinCount == 3 {
+		return true
+	}
+	return slices.Contains(f.Celebrities, n.Celebrity.ID)
+}
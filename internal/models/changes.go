@@ -0,0 +1,90 @@
+package models
+
+import "sort"
+
+// AwardChanges describes how a celebrity's awards changed when written
+type AwardChanges struct {
+	NewWins        []Award // past wins that were not wins before
+	NewNominations []Award // awards that did not exist before and are not past wins
+	// FirstWins is the earliest win of each award type not won before, in
+	// the order they were won
+	FirstWins []Award
+	Progress  EGOTProgressChange
+}
+
+// ProgressChanged reports whether the number of distinct EGOT awards won changed
+func (c AwardChanges) ProgressChanged() bool {
+	return c.Progress.Previous != c.Progress.Current
+}
+
+// IsEmpty reports whether nothing changed
+func (c AwardChanges) IsEmpty() bool {
+	return len(c.NewWins) == 0 && len(c.NewNominations) == 0 && !c.ProgressChanged()
+}
+
+// DiffAwards compares a celebrity's awards before and after a write.
+// Awards are matched on their natural key.
+func DiffAwards(before, after []Award) AwardChanges {
+	previous := make(map[string]Award, len(before))
+	for _, a := range before {
+		previous[a.NaturalKey()] = a
+	}
+
+	var changes AwardChanges
+	for _, a := range after {
+		old, existed := previous[a.NaturalKey()]
+		switch {
+		case isPastWin(a) && (!existed || !isPastWin(old)):
+			changes.NewWins = append(changes.NewWins, a)
+		case !isPastWin(a) && !existed:
+			changes.NewNominations = append(changes.NewNominations, a)
+		}
+	}
+
+	wonBefore := make(map[AwardType]bool)
+	for _, a := range before {
+		if isPastWin(a) {
+			wonBefore[a.Type] = true
+		}
+	}
+	first := make(map[AwardType]Award)
+	for _, a := range after {
+		if !isPastWin(a) || wonBefore[a.Type] {
+			continue
+		}
+		if current, seen := first[a.Type]; !seen || earlierWin(a, current) {
+			first[a.Type] = a
+		}
+	}
+	for _, a := range first {
+		changes.FirstWins = append(changes.FirstWins, a)
+	}
+	sort.Slice(changes.FirstWins, func(i, j int) bool {
+		a, b := changes.FirstWins[i], changes.FirstWins[j]
+		if earlierWin(a, b) != earlierWin(b, a) {
+			return earlierWin(a, b)
+		}
+		return egotLetters[a.Type] < egotLetters[b.Type]
+	})
+
+	changes.Progress = EGOTProgressChange{
+		Previous: EGOTWinCount(before),
+		Current:  EGOTWinCount(after),
+	}
+	return changes
+}
+
+// EGOTWinCount returns the number of distinct EGOT awards won
+func EGOTWinCount(awards []Award) int {
+	won := make(map[AwardType]bool)
+	for _, a := range awards {
+		if isPastWin(a) {
+			won[a.Type] = true
+		}
+	}
+	return len(won)
+}
+
+func isPastWin(a Award) bool {
+	return a.IsWinner && !a.IsUpcoming
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func award(awardType AwardType, year int, category string, winner, upcoming bool) Award {
+	return Award{Type: awardType, Year: year, Category: category, Work: "Work", IsWinner: winner, IsUpcoming: upcoming}
+}
+
+// categories returns the categories of awards, which identify them in these
+// tests
+func categories(awards []Award) []string {
+	var out []string
+	for _, a := range awards {
+		out = append(out, a.Category)
+	}
+	return out
+}
+
+func TestDiffAwards(t *testing.T) {
+	emmyNom := award(AwardTypeEmmy, 2019, "Emmy nomination", false, false)
+	emmyWin := award(AwardTypeEmmy, 2019, "Emmy nomination", true, false)
+	grammyWin := award(AwardTypeGrammy, 2015, "Grammy win", true, false)
+	oscarWin := award(AwardTypeOscar, 2021, "Oscar win", true, false)
+	tonyWin := award(AwardTypeTony, 2010, "Tony win", true, false)
+	tonyNom := award(AwardTypeTony, 2024, "Tony nomination", false, false)
+	oscarUpcoming := award(AwardTypeOscar, 2027, "Oscar upcoming", true, true)
+
+	tests := []struct {
+		name            string
+		before, after   []Award
+		wantWins        []string
+		wantNominations []string
+		wantFirstWins   []string
+		wantProgress    EGOTProgressChange
+		wantEmpty       bool
+	}{
+		{
+			name:         "no change",
+			before:       []Award{grammyWin, emmyNom},
+			after:        []Award{grammyWin, emmyNom},
+			wantProgress: EGOTProgressChange{Previous: 1, Current: 1},
+			wantEmpty:    true,
+		},
+		{
+			name:            "new nomination",
+			before:          []Award{grammyWin},
+			after:           []Award{grammyWin, tonyNom},
+			wantNominations: []string{"Tony nomination"},
+			wantProgress:    EGOTProgressChange{Previous: 1, Current: 1},
+		},
+		{
+			name:          "nomination becomes a win",
+			before:        []Award{grammyWin, emmyNom},
+			after:         []Award{grammyWin, emmyWin},
+			wantWins:      []string{"Emmy nomination"},
+			wantFirstWins: []string{"Emmy nomination"},
+			wantProgress:  EGOTProgressChange{Previous: 1, Current: 2},
+		},
+		{
+			name:         "new win of an award type already won",
+			before:       []Award{grammyWin},
+			after:        []Award{grammyWin, award(AwardTypeGrammy, 2018, "Another Grammy", true, false)},
+			wantWins:     []string{"Another Grammy"},
+			wantProgress: EGOTProgressChange{Previous: 1, Current: 1},
+		},
+		{
+			name:            "upcoming win is a nomination",
+			before:          []Award{grammyWin},
+			after:           []Award{grammyWin, oscarUpcoming},
+			wantNominations: []string{"Oscar upcoming"},
+			wantProgress:    EGOTProgressChange{Previous: 1, Current: 1},
+		},
+		{
+			name:            "first import orders first wins by date",
+			after:           []Award{oscarWin, grammyWin, tonyNom, tonyWin},
+			wantWins:        []string{"Oscar win", "Grammy win", "Tony win"},
+			wantNominations: []string{"Tony nomination"},
+			wantFirstWins:   []string{"Tony win", "Grammy win", "Oscar win"},
+			wantProgress:    EGOTProgressChange{Previous: 0, Current: 3},
+		},
+		{
+			name:          "completing the EGOT",
+			before:        []Award{grammyWin, oscarWin, tonyWin, emmyNom},
+			after:         []Award{grammyWin, oscarWin, tonyWin, emmyWin},
+			wantWins:      []string{"Emmy nomination"},
+			wantFirstWins: []string{"Emmy nomination"},
+			wantProgress:  EGOTProgressChange{Previous: 3, Current: 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffAwards(tt.before, tt.after)
+
+			if wins := categories(got.NewWins); !slices.Equal(wins, tt.wantWins) {
+				t.Errorf("NewWins = %v, want %v", wins, tt.wantWins)
+			}
+			if noms := categories(got.NewNominations); !slices.Equal(noms, tt.wantNominations) {
+				t.Errorf("NewNominations = %v, want %v", noms, tt.wantNominations)
+			}
+			if first := categories(got.FirstWins); !slices.Equal(first, tt.wantFirstWins) {
+				t.Errorf("FirstWins = %v, want %v", first, tt.wantFirstWins)
+			}
+			if got.Progress != tt.wantProgress {
+				t.Errorf("Progress = %+v, want %+v", got.Progress, tt.wantProgress)
+			}
+			if got.IsEmpty() != tt.wantEmpty {
+				t.Errorf("IsEmpty() = %v, want %v", got.IsEmpty(), tt.wantEmpty)
+			}
+		})
+	}
+}
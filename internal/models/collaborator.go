@@ -0,0 +1,30 @@
+package models
+
+// WorkWin records that a celebrity won an award for a work
+type WorkWin struct {
+	Celebrity Celebrity
+	Work      Work
+}
+
+// Collaborator is a celebrity who won awards for the same works as another
+type Collaborator struct {
+	Celebrity
+	SharedWorks []Work `json:"shared_works"`
+}
+
+// PathStep is one hop in a chain of collaborators: From and To both won
+// awards for Work
+type PathStep struct {
+	From Celebrity `json:"from"`
+	Work Work      `json:"work"`
+	To   Celebrity `json:"to"`
+}
+
+// CollaborationPath is the shortest chain of shared award-winning works
+// between two celebrities
+type CollaborationPath struct {
+	From    Celebrity  `json:"from"`
+	To      Celebrity  `json:"to"`
+	Degrees int        `json:"degrees"`
+	Steps   []PathStep `json:"steps"`
+}
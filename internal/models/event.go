@@ -0,0 +1,78 @@
+package models
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// EGOTEventType is a milestone in a celebrity's EGOT progress
+type EGOTEventType string
+
+const (
+	EventFirstWin      EGOTEventType = "first_win"      // first win of an award type
+	EventReachedThree  EGOTEventType = "reached_three"  // won 3 of the 4 awards
+	EventCompletedEGOT EGOTEventType = "completed_egot" // won all 4 awards
+)
+
+// EventAward is the award that triggered an event, as it was at the time
+type EventAward struct {
+	ID       pgtype.UUID `json:"id"`
+	Type     AwardType   `json:"type"`
+	Year     int         `json:"year"`
+	Category string      `json:"category"`
+	Work     string      `json:"work"`
+}
+
+// EGOTEvent records when a celebrity crossed an EGOT milestone
+type EGOTEvent struct {
+	ID            pgtype.UUID        `json:"id"`
+	Type          EGOTEventType      `json:"type"`
+	Celebrity     Celebrity          `json:"celebrity"`
+	EGOTWinCount  int                `json:"egot_win_count"` // after the event
+	Award         EventAward         `json:"award"`
+	InitialImport bool               `json:"initial_import"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+// EGOTEventFilter selects events for the events endpoint. Empty fields do
+// not filter.
+type EGOTEventFilter struct {
+	Types          []EGOTEventType
+	CelebrityID    pgtype.UUID
+	ExcludeInitial bool
+}
+
+// EGOTEvents returns the milestones crossed by a change to a celebrity's
+// awards, in the order they were reached
+func (c AwardChanges) EGOTEvents(celebrity Celebrity, initialImport bool) []EGOTEvent {
+	var events []EGOTEvent
+	count := c.Progress.Previous
+	for _, a := range c.FirstWins {
+		count++
+		award := EventAward{ID: a.ID, Type: a.Type, Year: a.Year, Category: a.Category, Work: a.Work}
+
+		events = append(events, EGOTEvent{
+			Type:          EventFirstWin,
+			Celebrity:     celebrity,
+			EGOTWinCount:  count,
+			Award:         award,
+			InitialImport: initialImport,
+		})
+		switch count {
+		case 3:
+			events = append(events, EGOTEvent{
+				Type:          EventReachedThree,
+				Celebrity:     celebrity,
+				EGOTWinCount:  count,
+				Award:         award,
+				InitialImport: initialImport,
+			})
+		case 4:
+			events = append(events, EGOTEvent{
+				Type:          EventCompletedEGOT,
+				Celebrity:     celebrity,
+				EGOTWinCount:  count,
+				Award:         award,
+				InitialImport: initialImport,
+			})
+		}
+	}
+	return events
+}
@@ -0,0 +1,27 @@
+package models
+
+// LeaderboardMetric is a statistic celebrities can be ranked by
+type LeaderboardMetric string
+
+const (
+	MetricEmmys       LeaderboardMetric = "emmys"       // Emmy wins
+	MetricGrammys     LeaderboardMetric = "grammys"     // Grammy wins
+	MetricOscars      LeaderboardMetric = "oscars"      // Oscar wins
+	MetricTonys       LeaderboardMetric = "tonys"       // Tony wins
+	MetricTotalWins   LeaderboardMetric = "total_wins"  // wins across all four awards
+	MetricNominations LeaderboardMetric = "nominations" // award rows, won or not
+	MetricWinSpan     LeaderboardMetric = "win_span"    // years from first to last win
+)
+
+// AllLeaderboardMetrics lists the metrics served by the leaderboard endpoint
+var AllLeaderboardMetrics = []LeaderboardMetric{
+	MetricEmmys, MetricGrammys, MetricOscars, MetricTonys,
+	MetricTotalWins, MetricNominations, MetricWinSpan,
+}
+
+// LeaderboardEntry is a celebrity's position on a leaderboard
+type LeaderboardEntry struct {
+	CelebrityWithEGOTProgress
+	Rank  int `json:"rank"`
+	Value int `json:"value"`
+}
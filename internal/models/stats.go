@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AwardTypeStats counts the awards of one type and the people holding them
+type AwardTypeStats struct {
+	Type        AwardType `json:"type"`
+	Winners     int64     `json:"winners"` // celebrities with at least one win
+	Wins        int64     `json:"wins"`
+	Nominations int64     `json:"nominations"` // every past award row, won or not
+}
+
+// DecadeStats counts past awards in one decade, e.g. 1990 for 1990-1999
+type DecadeStats struct {
+	Decade      int                 `json:"decade"`
+	Wins        int64               `json:"wins"`
+	Nominations int64               `json:"nominations"`
+	WinsByType  map[AwardType]int64 `json:"wins_by_type"`
+}
+
+// SubBodyStats counts past awards presented by one body within an award
+// type, such as Daytime vs Primetime Emmys
+type SubBodyStats struct {
+	Type        AwardType `json:"type"`
+	SubBody     string    `json:"sub_body"`
+	Wins        int64     `json:"wins"`
+	Nominations int64     `json:"nominations"`
+}
+
+// Stats summarises the tracked celebrities and their awards
+type Stats struct {
+	Celebrities int64            `json:"celebrities"`
+	ByAwardType []AwardTypeStats `json:"by_award_type"`
+	ByDecade    []DecadeStats    `json:"by_decade"`
+	ByProgress  map[int]int64    `json:"by_progress"` // distinct EGOT awards won -> celebrities
+	BySubBody   []SubBodyStats   `json:"by_sub_body"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// egotLetters maps each award type to its letter in "EGOT"
+var egotLetters = map[AwardType]string{
+	AwardTypeEmmy:   "E",
+	AwardTypeGrammy: "G",
+	AwardTypeOscar:  "O",
+	AwardTypeTony:   "T",
+}
+
+// EGOTMilestone is the first win of one EGOT award type
+type EGOTMilestone struct {
+	Type         AwardType   `json:"type"`
+	Letter       string      `json:"letter"`
+	Year         int         `json:"year"` // 0 if the year is unknown
+	AwardID      pgtype.UUID `json:"award_id"`
+	Work         string      `json:"work"`
+	Category     string      `json:"category"`
+	CeremonyDate pgtype.Date `json:"ceremony_date,omitempty"`
+}
+
+// EGOTTimeline describes when and in what order a celebrity won each award
+type EGOTTimeline struct {
+	Milestones    []EGOTMilestone `json:"milestones"`
+	Order         string          `json:"order"` // e.g. "G→E→T→O"
+	IsComplete    bool            `json:"is_complete"`
+	CompletedYear *int            `json:"completed_year"`
+	CompletedDate *time.Time      `json:"completed_date"`
+	SpanYears     *int            `json:"span_years"` // first to fourth letter
+}
+
+// BuildEGOTTimeline computes a celebrity's EGOT timeline from their awards.
+// Only past wins count. Wins with an unknown year (0) are used only when no
+// dated win of that type exists, and make the completion year unknown.
+func BuildEGOTTimeline(awards []Award) EGOTTimeline {
+	first := make(map[AwardType]Award)
+	for _, a := range awards {
+		if !a.IsWinner || a.IsUpcoming {
+			continue
+		}
+		current, seen := first[a.Type]
+		if !seen || earlierWin(a, current) {
+			first[a.Type] = a
+		}
+	}
+
+	timeline := EGOTTimeline{Milestones: make([]EGOTMilestone, 0, len(first))}
+	for _, a := range first {
+		timeline.Milestones = append(timeline.Milestones, EGOTMilestone{
+			Type:         a.Type,
+			Letter:       egotLetters[a.Type],
+			Year:         a.Year,
+			AwardID:      a.ID,
+			Work:         a.Work,
+			Category:     a.Category,
+			CeremonyDate: a.CeremonyDate,
+		})
+	}
+	sort.Slice(timeline.Milestones, func(i, j int) bool {
+		return milestoneBefore(timeline.Milestones[i], timeline.Milestones[j])
+	})
+
+	letters := make([]string, len(timeline.Milestones))
+	yearsKnown := true
+	for i, m := range timeline.Milestones {
+		letters[i] = m.Letter
+		if m.Year == 0 {
+			yearsKnown = false
+		}
+	}
+	timeline.Order = strings.Join(letters, "→")
+	timeline.IsComplete = len(timeline.Milestones) == len(egotLetters)
+
+	if timeline.IsComplete && yearsKnown {
+		firstWin, last := timeline.Milestones[0], timeline.Milestones[len(timeline.Milestones)-1]
+		completed := last.Year
+		span := last.Year - firstWin.Year
+		timeline.CompletedYear = &completed
+		timeline.SpanYears = &span
+		if last.CeremonyDate.Valid {
+			date := last.CeremonyDate.Time
+			timeline.CompletedDate = &date
+		}
+	}
+
+	return timeline
+}
+
+// earlierWin reports whether a is an earlier win than b, treating an
+// unknown year as later than any known one
+func earlierWin(a, b Award) bool {
+	if (a.Year == 0) != (b.Year == 0) {
+		return b.Year == 0
+	}
+	if a.Year != b.Year {
+		return a.Year < b.Year
+	}
+	if a.CeremonyDate.Valid && b.CeremonyDate.Valid {
+		return a.CeremonyDate.Time.Before(b.CeremonyDate.Time)
+	}
+	return a.CeremonyDate.Valid
+}
+
+// milestoneBefore orders milestones by year, then ceremony date, then EGOT
+// letter order, with unknown years last
+func milestoneBefore(a, b EGOTMilestone) bool {
+	if (a.Year == 0) != (b.Year == 0) {
+		return b.Year == 0
+	}
+	if a.Year != b.Year {
+		return a.Year < b.Year
+	}
+	if a.CeremonyDate.Valid && b.CeremonyDate.Valid && !a.CeremonyDate.Time.Equal(b.CeremonyDate.Time) {
+		return a.CeremonyDate.Time.Before(b.CeremonyDate.Time)
+	}
+	return egotLetters[a.Type] < egotLetters[b.Type]
+}
+
+// CelebrityEGOTTimeline pairs an EGOT winner with their timeline
+type CelebrityEGOTTimeline struct {
+	Celebrity
+	Timeline EGOTTimeline `json:"timeline"`
+}
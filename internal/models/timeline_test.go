@@ -0,0 +1,191 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// on returns a with its ceremony held on date, given as YYYY-MM-DD
+func on(a Award, date string) Award {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		panic(err)
+	}
+	a.CeremonyDate = pgtype.Date{Time: t, Valid: true}
+	return a
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestBuildEGOTTimeline(t *testing.T) {
+	emmy := award(AwardTypeEmmy, 2005, "Emmy", true, false)
+	grammy := award(AwardTypeGrammy, 2000, "Grammy", true, false)
+	oscar := award(AwardTypeOscar, 2015, "Oscar", true, false)
+	tony := award(AwardTypeTony, 2010, "Tony", true, false)
+
+	tests := []struct {
+		name          string
+		awards        []Award
+		wantOrder     string
+		wantYears     []int
+		wantCategory  map[AwardType]string // milestone category, where it matters
+		wantComplete  bool
+		wantCompleted *int
+		wantSpan      *int
+		wantDate      string
+	}{
+		{
+			name:          "completion order",
+			awards:        []Award{oscar, emmy, tony, grammy},
+			wantOrder:     "G→E→T→O",
+			wantYears:     []int{2000, 2005, 2010, 2015},
+			wantComplete:  true,
+			wantCompleted: intPtr(2015),
+			wantSpan:      intPtr(15),
+		},
+		{
+			name:          "completion date is the last ceremony's",
+			awards:        []Award{emmy, grammy, tony, on(oscar, "2015-02-22")},
+			wantOrder:     "G→E→T→O",
+			wantYears:     []int{2000, 2005, 2010, 2015},
+			wantComplete:  true,
+			wantCompleted: intPtr(2015),
+			wantSpan:      intPtr(15),
+			wantDate:      "2015-02-22",
+		},
+		{
+			name:         "earliest win of each type",
+			awards:       []Award{award(AwardTypeGrammy, 2012, "Later Grammy", true, false), grammy},
+			wantOrder:    "G",
+			wantYears:    []int{2000},
+			wantCategory: map[AwardType]string{AwardTypeGrammy: "Grammy"},
+		},
+		{
+			name: "same year ordered by ceremony date",
+			awards: []Award{
+				on(award(AwardTypeEmmy, 2005, "Emmy", true, false), "2005-09-18"),
+				on(award(AwardTypeGrammy, 2005, "Grammy", true, false), "2005-02-13"),
+			},
+			wantOrder: "G→E",
+			wantYears: []int{2005, 2005},
+		},
+		{
+			name: "same year without dates in EGOT order",
+			awards: []Award{
+				award(AwardTypeTony, 2005, "Tony", true, false),
+				award(AwardTypeEmmy, 2005, "Emmy", true, false),
+			},
+			wantOrder: "E→T",
+			wantYears: []int{2005, 2005},
+		},
+		{
+			name: "same type and year prefers the earlier ceremony",
+			awards: []Award{
+				on(award(AwardTypeEmmy, 2005, "September Emmy", true, false), "2005-09-18"),
+				on(award(AwardTypeEmmy, 2005, "May Emmy", true, false), "2005-05-20"),
+			},
+			wantOrder:    "E",
+			wantYears:    []int{2005},
+			wantCategory: map[AwardType]string{AwardTypeEmmy: "May Emmy"},
+		},
+		{
+			name: "same type and year prefers a known ceremony date",
+			awards: []Award{
+				award(AwardTypeEmmy, 2005, "Undated Emmy", true, false),
+				on(award(AwardTypeEmmy, 2005, "Dated Emmy", true, false), "2005-09-18"),
+			},
+			wantOrder:    "E",
+			wantYears:    []int{2005},
+			wantCategory: map[AwardType]string{AwardTypeEmmy: "Dated Emmy"},
+		},
+		{
+			name:         "a dated win beats one with an unknown year",
+			awards:       []Award{award(AwardTypeOscar, 0, "Undated Oscar", true, false), oscar},
+			wantOrder:    "O",
+			wantYears:    []int{2015},
+			wantCategory: map[AwardType]string{AwardTypeOscar: "Oscar"},
+		},
+		{
+			name:         "unknown year sorts last and hides the completion year",
+			awards:       []Award{award(AwardTypeGrammy, 0, "Undated Grammy", true, false), emmy, tony, oscar},
+			wantOrder:    "E→T→O→G",
+			wantYears:    []int{2005, 2010, 2015, 0},
+			wantComplete: true,
+		},
+		{
+			name: "nominations and upcoming wins are left out",
+			awards: []Award{
+				grammy,
+				emmy,
+				tony,
+				award(AwardTypeOscar, 2015, "Oscar nomination", false, false),
+				award(AwardTypeOscar, 2027, "Upcoming Oscar", true, true),
+			},
+			wantOrder: "G→E→T",
+			wantYears: []int{2000, 2005, 2010},
+		},
+		{
+			name:      "no wins",
+			awards:    []Award{award(AwardTypeEmmy, 2005, "Emmy nomination", false, false)},
+			wantOrder: "",
+			wantYears: []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BuildEGOTTimeline(tt.awards)
+
+			if got.Order != tt.wantOrder {
+				t.Errorf("Order = %q, want %q", got.Order, tt.wantOrder)
+			}
+			years := make([]int, len(got.Milestones))
+			for i, m := range got.Milestones {
+				years[i] = m.Year
+				if want, ok := tt.wantCategory[m.Type]; ok && m.Category != want {
+					t.Errorf("%s milestone category = %q, want %q", m.Type, m.Category, want)
+				}
+			}
+			if !slices.Equal(years, tt.wantYears) {
+				t.Errorf("milestone years = %v, want %v", years, tt.wantYears)
+			}
+			if got.IsComplete != tt.wantComplete {
+				t.Errorf("IsComplete = %v, want %v", got.IsComplete, tt.wantComplete)
+			}
+			if !equalIntPtr(got.CompletedYear, tt.wantCompleted) {
+				t.Errorf("CompletedYear = %v, want %v", deref(got.CompletedYear), deref(tt.wantCompleted))
+			}
+			if !equalIntPtr(got.SpanYears, tt.wantSpan) {
+				t.Errorf("SpanYears = %v, want %v", deref(got.SpanYears), deref(tt.wantSpan))
+			}
+
+			var date string
+			if got.CompletedDate != nil {
+				date = got.CompletedDate.Format(time.DateOnly)
+			}
+			if date != tt.wantDate {
+				t.Errorf("CompletedDate = %q, want %q", date, tt.wantDate)
+			}
+		})
+	}
+}
+
+func equalIntPtr(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// deref formats an optional int for test failures
+func deref(n *int) any {
+	if n == nil {
+		return nil
+	}
+	return *n
+}
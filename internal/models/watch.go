@@ -0,0 +1,22 @@
+package models
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// PendingNomination is an upcoming nomination for an award the nominee has
+// not yet won
+type PendingNomination struct {
+	Award     Award                     `json:"award"`
+	Celebrity CelebrityWithEGOTProgress `json:"celebrity"`
+	// CompletesEGOT is true if winning would give the nominee all four
+	// awards, and false if it would take them to three
+	CompletesEGOT bool `json:"completes_egot"`
+}
+
+// UpcomingCeremony groups the pending nominations at one ceremony that would
+// take someone to 3/4 or complete their EGOT
+type UpcomingCeremony struct {
+	Type         AwardType           `json:"type"`
+	Year         int                 `json:"year"`
+	CeremonyDate pgtype.Date         `json:"ceremony_date"` // null if not yet announced
+	Nominations  []PendingNomination `json:"nominations"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// APIKey identifies a client of the authenticated endpoints
+type APIKey struct {
+	ID        pgtype.UUID        `json:"id" db:"id"`
+	Name      string             `json:"name" db:"name"`
+	IsAdmin   bool               `json:"is_admin" db:"is_admin"`
+	CreatedAt pgtype.Timestamptz `json:"created_at" db:"created_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at" db:"revoked_at"`
+}
+
+// Watchlist is a set of celebrities whose award changes are sent to a webhook
+type Watchlist struct {
+	ID            pgtype.UUID        `json:"id" db:"id"`
+	APIKeyID      pgtype.UUID        `json:"-" db:"api_key_id"`
+	Name          string             `json:"name" db:"name"`
+	WebhookURL    pgtype.Text        `json:"webhook_url" db:"webhook_url"`
+	WebhookSecret string             `json:"-" db:"webhook_secret"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at" db:"created_at"`
+}
+
+// WatchlistWithCelebrities is a watchlist with the celebrities on it
+type WatchlistWithCelebrities struct {
+	Watchlist
+	Celebrities []Celebrity `json:"celebrities"`
+}
+
+// WebhookEventType is the kind of change a webhook delivery reports
+type WebhookEventType string
+
+const (
+	EventAwardWon            WebhookEventType = "award.won"
+	EventNominationAdded     WebhookEventType = "nomination.added"
+	EventEGOTProgressChanged WebhookEventType = "egot_progress.changed"
+)
+
+// EGOTProgressChange is a change in the number of distinct EGOT awards won
+type EGOTProgressChange struct {
+	Previous int `json:"previous"`
+	Current  int `json:"current"`
+}
+
+// WebhookEvent is the JSON body of a webhook delivery
+type WebhookEvent struct {
+	Type      WebhookEventType    `json:"type"`
+	Celebrity Celebrity           `json:"celebrity"`
+	Award     *Award              `json:"award,omitempty"`
+	Progress  *EGOTProgressChange `json:"progress,omitempty"`
+	CreatedAt pgtype.Timestamptz  `json:"created_at"`
+}
+
+// WebhookDeliveryStatus is the state of a delivery in the outbox
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliveryDelivered WebhookDeliveryStatus = "delivered"
+	DeliveryFailed    WebhookDeliveryStatus = "failed" // gave up after retries
+)
+
+// WebhookDelivery is one event queued for one watchlist's webhook
+type WebhookDelivery struct {
+	ID            pgtype.UUID           `json:"id" db:"id"`
+	WatchlistID   pgtype.UUID           `json:"watchlist_id" db:"watchlist_id"`
+	EventType     WebhookEventType      `json:"event_type" db:"event_type"`
+	Payload       json.RawMessage       `json:"payload" db:"payload"`
+	Status        WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts      int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt pgtype.Timestamptz    `json:"next_attempt_at" db:"next_attempt_at"`
+	CreatedAt     pgtype.Timestamptz    `json:"created_at" db:"created_at"`
+	DeliveredAt   pgtype.Timestamptz    `json:"delivered_at" db:"delivered_at"`
+
+	// DedupeKey identifies the change reported; a watchlist is sent each
+	// change once
+	DedupeKey string `json:"-" db:"dedupe_key"`
+
+	// Where to send it, loaded when the delivery is claimed for sending
+	WebhookURL    string `json:"-"`
+	WebhookSecret string `json:"-"`
+}
+
+// WebhookDeliveryAttempt is the outcome of one attempt to send a delivery
+type WebhookDeliveryAttempt struct {
+	ID          pgtype.UUID        `json:"id" db:"id"`
+	DeliveryID  pgtype.UUID        `json:"delivery_id" db:"delivery_id"`
+	AttemptedAt pgtype.Timestamptz `json:"attempted_at" db:"attempted_at"`
+	StatusCode  pgtype.Int4        `json:"status_code" db:"status_code"`
+	Error       pgtype.Text        `json:"error" db:"error"`
+	DurationMs  int                `json:"duration_ms" db:"duration_ms"`
+}
+
+// WebhookDeliveryWithAttempts is a delivery with its attempt log
+type WebhookDeliveryWithAttempts struct {
+	WebhookDelivery
+	AttemptLog []WebhookDeliveryAttempt `json:"attempt_log"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// WorkType is the kind of work an award was given for
+type WorkType string
+
+const (
+	WorkTypeFilm   WorkType = "film"
+	WorkTypeSeries WorkType = "series"
+	WorkTypeAlbum  WorkType = "album"
+	WorkTypePlay   WorkType = "play"
+	WorkTypeOther  WorkType = "other"
+)
+
+// Work is a film, series, album or play identified by its Wikidata item
+type Work struct {
+	ID         pgtype.UUID        `json:"id" db:"id"`
+	WikidataID string             `json:"wikidata_id" db:"wikidata_id"`
+	Title      string             `json:"title" db:"title"`
+	Type       WorkType           `json:"type" db:"type"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at" db:"created_at"`
+}
+
+// WorkCredit is one tracked celebrity's awards for a work
+type WorkCredit struct {
+	Celebrity Celebrity `json:"celebrity"`
+	Awards    []Award   `json:"awards"`
+}
+
+// WorkWithCredits is a work with every tracked celebrity's awards for it
+type WorkWithCredits struct {
+	Work
+	Credits []WorkCredit `json:"credits"`
+}
@@ -0,0 +1,390 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/metrics"
+	"egot-tracker/pkg/response"
+)
+
+// Config describes the API served through a Router
+type Config struct {
+	Info Info
+	// Operations documents routes by their ServeMux pattern
+	Operations map[string]Operation
+	// Enums are slices listing every value of a named string type, used
+	// wherever that type appears in a schema
+	Enums []any
+	// Validate checks every request and response against the document
+	Validate bool
+}
+
+// Router registers handlers on a ServeMux and records each route in the
+// OpenAPI document
+type Router struct {
+	mux       *http.ServeMux
+	config    Config
+	gen       *generator
+	paths     map[string]*PathItem
+	validator *validator
+
+	prefix      string
+	deprecation *Deprecation
+}
+
+// Deprecation describes routes kept for clients of an older API version
+type Deprecation struct {
+	// Since is when the routes were deprecated
+	Since time.Time
+	// Sunset is when the routes will be removed
+	Sunset time.Time
+	// Successor is the prefix the replacing routes are served under
+	Successor string
+}
+
+// route is a registered route with its generated schemas
+type route struct {
+	operation Operation
+	params    []*Param
+	body      *Schema
+	status    int
+	response  *Schema // nil if the success response is not JSON
+	errors    *Schema
+}
+
+var wildcardPattern = regexp.MustCompile(`\{([^}.]+)(\.\.\.)?\}`)
+
+func NewRouter(mux *http.ServeMux, config Config) *Router {
+	gen := newGenerator(config.Enums)
+	return &Router{
+		mux:       mux,
+		config:    config,
+		gen:       gen,
+		paths:     make(map[string]*PathItem),
+		validator: &validator{schemas: gen.schemas},
+	}
+}
+
+// WithPrefix returns a Router registering routes under prefix. Operations
+// are still looked up by the unprefixed pattern, so the same routes can be
+// mounted under several prefixes.
+func (r *Router) WithPrefix(prefix string) *Router {
+	prefixed := *r
+	prefixed.prefix = r.prefix + prefix
+	return &prefixed
+}
+
+// Deprecated returns a Router whose routes are marked deprecated in the
+// document and send Deprecation, Sunset and successor Link headers
+func (r *Router) Deprecated(deprecation Deprecation) *Router {
+	deprecated := *r
+	deprecated.deprecation = &deprecation
+	return &deprecated
+}
+
+// ServeHTTP dispatches requests to the registered routes. Requests matching
+// no route, or only routes for other methods, get the same problem responses
+// as handlers rather than ServeMux's plain text errors.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler, pattern := r.mux.Handler(req)
+	if pattern != "" {
+		metrics.SetRoute(req.Context(), pattern)
+		r.mux.ServeHTTP(w, req)
+		return
+	}
+
+	// Let ServeMux decide between 404 and 405 and set the Allow header
+	rec := &recorder{header: w.Header(), status: http.StatusOK}
+	handler.ServeHTTP(rec, req)
+	switch rec.status {
+	case http.StatusMethodNotAllowed:
+		response.WriteProblem(w, rec.status, "method_not_allowed", req.Method+" is not allowed on "+req.URL.Path, nil)
+	case http.StatusNotFound:
+		response.WriteProblem(w, rec.status, "route_not_found", "no route matches "+req.URL.Path, nil)
+	default:
+		// Redirects, such as to add a trailing slash
+		w.WriteHeader(rec.status)
+		w.Write(rec.body.Bytes())
+	}
+}
+
+// HandleFunc registers handler for pattern, which must include a method
+func (r *Router) HandleFunc(pattern string, handler http.HandlerFunc) {
+	method, path, _ := strings.Cut(pattern, " ")
+	operation, documented := r.config.Operations[pattern]
+	if !documented {
+		slog.Warn("openapi: route is not documented", "pattern", pattern)
+	}
+
+	path = r.prefix + path
+	rt := r.describe(method, path, operation)
+	if r.config.Validate {
+		handler = r.validating(rt, handler)
+	}
+	if r.deprecation != nil {
+		handler = r.deprecating(handler)
+	}
+	r.mux.HandleFunc(method+" "+path, handler)
+}
+
+// deprecating wraps a handler to announce its deprecation (RFC 9745), its
+// sunset (RFC 8594) and the route replacing it
+func (r *Router) deprecating(next http.HandlerFunc) http.HandlerFunc {
+	deprecation := "@" + strconv.FormatInt(r.deprecation.Since.Unix(), 10)
+	sunset := r.deprecation.Sunset.UTC().Format(http.TimeFormat)
+	return func(w http.ResponseWriter, req *http.Request) {
+		successor := r.deprecation.Successor + strings.TrimPrefix(req.URL.Path, r.prefix)
+		if req.URL.RawQuery != "" {
+			successor += "?" + req.URL.RawQuery
+		}
+		w.Header().Set("Deprecation", deprecation)
+		w.Header().Set("Sunset", sunset)
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+		next(w, req)
+	}
+}
+
+// describe adds an operation to the document
+func (r *Router) describe(method, path string, operation Operation) *route {
+	rt := &route{
+		operation: operation,
+		params:    operation.Params,
+		status:    operation.Status,
+		errors:    r.gen.schemaOf(response.Problem{}),
+	}
+	if rt.status == 0 {
+		rt.status = http.StatusOK
+	}
+
+	// Every wildcard needs a path parameter, even if undocumented
+	for _, match := range wildcardPattern.FindAllStringSubmatch(path, -1) {
+		if !hasParam(rt.params, "path", match[1]) {
+			rt.params = append(rt.params, Path(match[1], "", String()))
+		}
+	}
+	path = wildcardPattern.ReplaceAllString(path, "{$1}")
+
+	op := &OperationObject{
+		OperationID: operationID(method, path),
+		Summary:     operation.Summary,
+		Description: operation.Description,
+		Parameters:  rt.params,
+		Responses:   make(map[string]*ResponseObject),
+	}
+	if operation.Tag != "" {
+		op.Tags = []string{operation.Tag}
+	}
+	if r.deprecation != nil {
+		op.Deprecated = true
+		op.Description = strings.TrimSpace(fmt.Sprintf("%s\n\nDeprecated: removed after %s, use %s%s instead.",
+			op.Description, r.deprecation.Sunset.Format(time.DateOnly), r.deprecation.Successor, strings.TrimPrefix(path, r.prefix)))
+	}
+	if operation.Auth {
+		op.Security = []map[string][]string{{"apiKey": {}}}
+	}
+	if operation.Body != nil {
+		rt.body = r.gen.schemaOf(operation.Body)
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{"application/json": {Schema: rt.body}},
+		}
+	}
+
+	success := &ResponseObject{Description: http.StatusText(rt.status)}
+	switch {
+	case operation.ContentType != "":
+		success.Content = map[string]*MediaType{operation.ContentType: {Schema: String()}}
+	case operation.Response != nil:
+		rt.response = r.gen.schemaOf(operation.Response)
+		success.Content = map[string]*MediaType{"application/json": {Schema: rt.response}}
+	}
+	op.Responses[strconv.Itoa(rt.status)] = success
+	op.Responses["default"] = &ResponseObject{
+		Description: "Error",
+		Content:     map[string]*MediaType{"application/problem+json": {Schema: rt.errors}},
+	}
+
+	item, ok := r.paths[path]
+	if !ok {
+		item = &PathItem{}
+		r.paths[path] = item
+	}
+	(*item)[strings.ToLower(method)] = op
+	return rt
+}
+
+// Document returns the OpenAPI document for the routes registered so far
+func (r *Router) Document() *Document {
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info:    r.config.Info,
+		Paths:   r.paths,
+		Components: Components{
+			Schemas: r.gen.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				"apiKey": {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+}
+
+// ServeDocument handles GET /openapi.json
+func (r *Router) ServeDocument(w http.ResponseWriter, req *http.Request) {
+	response.JSON(w, http.StatusOK, r.Document())
+}
+
+// validating wraps a handler to reject requests that do not match the
+// document and to log responses that do not, so handlers drifting from
+// their documentation are noticed during development
+func (r *Router) validating(rt *route, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		if problems := r.checkRequest(rt, req); len(problems) > 0 {
+			logging.FromContext(req.Context()).Warn("openapi: request does not match the API spec", "problems", problems)
+			fields := make(map[string]string, len(problems))
+			for _, problem := range problems {
+				field, message, _ := strings.Cut(problem, ": ")
+				fields[field] = message
+			}
+			response.WriteProblem(w, http.StatusBadRequest, "invalid_request", "request does not match the API spec", fields)
+			return
+		}
+
+		recorder := &recorder{header: w.Header(), status: http.StatusOK}
+		next(recorder, req)
+
+		if req.Method != http.MethodHead {
+			if problems := r.checkResponse(rt, recorder); len(problems) > 0 {
+				logging.FromContext(req.Context()).Warn("openapi: response does not match the API spec",
+					"status", recorder.status,
+					"problems", problems,
+				)
+				w.Header().Set("X-OpenAPI-Violation", problems[0])
+			}
+		}
+		w.WriteHeader(recorder.status)
+		w.Write(recorder.body.Bytes())
+	}
+}
+
+func (r *Router) checkRequest(rt *route, req *http.Request) []string {
+	var problems []string
+	query := req.URL.Query()
+	for _, param := range rt.params {
+		var raw string
+		var present bool
+		switch param.In {
+		case "path":
+			raw = req.PathValue(param.Name)
+			present = raw != ""
+		case "query":
+			raw = query.Get(param.Name)
+			present = query.Has(param.Name)
+		}
+		if !present {
+			if param.Required {
+				problems = append(problems, param.In+"."+param.Name+": is required")
+			}
+			continue
+		}
+		problems = append(problems, r.validator.validateParam(param, raw)...)
+	}
+
+	if rt.body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return append(problems, "body: "+err.Error())
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		value, err := decodeJSON(body)
+		if err != nil {
+			return append(problems, "body: invalid JSON")
+		}
+		problems = append(problems, r.validator.validate(rt.body, value, "body")...)
+	}
+	return problems
+}
+
+func (r *Router) checkResponse(rt *route, rec *recorder) []string {
+	var schema *Schema
+	switch {
+	case rec.status == rt.status:
+		if rt.operation.ContentType != "" {
+			return nil
+		}
+		if rt.response == nil {
+			if rec.body.Len() > 0 {
+				return []string{"body: expected no content"}
+			}
+			return nil
+		}
+		schema = rt.response
+	case rec.status >= 400:
+		schema = rt.errors
+	case rec.status >= 200 && rec.status < 300:
+		return []string{"status " + strconv.Itoa(rec.status) + " is not documented"}
+	default:
+		return nil
+	}
+
+	value, err := decodeJSON(rec.body.Bytes())
+	if err != nil {
+		return []string{"body: invalid JSON"}
+	}
+	return r.validator.validate(schema, value, "body")
+}
+
+// recorder buffers a response so it can be checked before it is sent
+type recorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (rec *recorder) Header() http.Header { return rec.header }
+
+func (rec *recorder) WriteHeader(status int) {
+	if !rec.wroteHeader {
+		rec.status = status
+		rec.wroteHeader = true
+	}
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.wroteHeader = true
+	return rec.body.Write(b)
+}
+
+func hasParam(params []*Param, in, name string) bool {
+	for _, param := range params {
+		if param.In == in && param.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// operationID derives an ID such as getApiCelebrityByIdAliases from a
+// method and path
+func operationID(method, path string) string {
+	var id strings.Builder
+	id.WriteString(strings.ToLower(method))
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '-' || r == '.' || r == '_' }) {
+		if strings.HasPrefix(segment, "{") {
+			id.WriteString("By")
+			segment = strings.Trim(segment, "{}")
+		}
+		id.WriteString(strings.ToUpper(segment[:1]) + segment[1:])
+	}
+	return id.String()
+}
@@ -0,0 +1,209 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// knownTypes are types with custom JSON encodings, described by hand
+var knownTypes = map[reflect.Type]Schema{
+	reflect.TypeOf(pgtype.UUID{}):        {Type: "string", Format: "uuid", Nullable: true},
+	reflect.TypeOf(pgtype.Text{}):        {Type: "string", Nullable: true},
+	reflect.TypeOf(pgtype.Date{}):        {Type: "string", Format: "date", Nullable: true},
+	reflect.TypeOf(pgtype.Timestamp{}):   {Type: "string", Format: "date-time", Nullable: true},
+	reflect.TypeOf(pgtype.Timestamptz{}): {Type: "string", Format: "date-time", Nullable: true},
+	reflect.TypeOf(pgtype.Int4{}):        {Type: "integer", Nullable: true},
+	reflect.TypeOf(pgtype.Int8{}):        {Type: "integer", Nullable: true},
+	reflect.TypeOf(pgtype.Bool{}):        {Type: "boolean", Nullable: true},
+	reflect.TypeOf(time.Time{}):          {Type: "string", Format: "date-time"},
+	reflect.TypeOf(json.RawMessage{}):    {},
+}
+
+var (
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// generator builds schemas from Go types the way encoding/json encodes
+// them. Named struct types become components referenced by $ref.
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+	enums   map[reflect.Type][]any
+}
+
+func newGenerator(enums []any) *generator {
+	g := &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+		enums:   make(map[reflect.Type][]any),
+	}
+	// Each enum is a slice of every value of a named string type
+	for _, values := range enums {
+		v := reflect.ValueOf(values)
+		for i := 0; i < v.Len(); i++ {
+			g.enums[v.Type().Elem()] = append(g.enums[v.Type().Elem()], v.Index(i).String())
+		}
+	}
+	return g
+}
+
+// schemaOf returns the schema of a value's type
+func (g *generator) schemaOf(value any) *Schema {
+	return g.schema(reflect.TypeOf(value))
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+	if known, ok := knownTypes[t]; ok {
+		return &known
+	}
+	if values, ok := g.enums[t]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	if t.Kind() == reflect.Pointer {
+		elem := g.schema(t.Elem())
+		return nullable(elem)
+	}
+	if t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType) {
+		return &Schema{}
+	}
+	if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		// nil slices encode as null
+		return &Schema{Type: "array", Items: g.schema(t.Elem()), Nullable: true}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+	return &Schema{}
+}
+
+// component registers a named struct type and returns its component name
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := componentName(t)
+	if _, taken := g.schemas[name]; taken {
+		name = strings.ReplaceAll(t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:], "-", "") + name
+	}
+	g.names[t] = name
+	// Register before generating so self-referencing types terminate
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.object(t)
+	return name
+}
+
+// object describes a struct's JSON object, promoting embedded fields
+func (g *generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t, 0, make(map[string]int))
+	return schema
+}
+
+// addFields adds a struct's fields at the given embedding depth. As in
+// encoding/json, a shallower field shadows deeper ones of the same name. A
+// format tag, such as `format:"date"`, sets the format of a string field.
+func (g *generator) addFields(schema *Schema, t reflect.Type, depth int, depths map[string]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				g.addFields(schema, fieldType, depth+1, depths)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if existing, ok := depths[name]; ok && existing <= depth {
+			continue
+		}
+		depths[name] = depth
+		property := g.schema(fieldType)
+		if format := field.Tag.Get("format"); format != "" {
+			formatted := *property
+			formatted.Format = format
+			property = &formatted
+		}
+		schema.Properties[name] = property
+		schema.Required = slices.DeleteFunc(schema.Required, func(r string) bool { return r == name })
+		if !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// componentName turns a type name into a component name, writing generic
+// instantiations such as Page[models.Award] as AwardPage
+func componentName(t reflect.Type) string {
+	base, args, generic := strings.Cut(t.Name(), "[")
+	if !generic {
+		return base
+	}
+	var name strings.Builder
+	for _, arg := range strings.Split(strings.TrimSuffix(args, "]"), ",") {
+		arg = arg[strings.LastIndex(arg, ".")+1:]
+		name.WriteString(strings.ToUpper(arg[:1]) + arg[1:])
+	}
+	name.WriteString(base)
+	return name.String()
+}
+
+// nullable marks a schema as accepting null. A $ref cannot carry other
+// keywords, so it is wrapped in allOf.
+func nullable(schema *Schema) *Schema {
+	if schema.Ref != "" {
+		return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+	}
+	copied := *schema
+	copied.Nullable = true
+	return &copied
+}
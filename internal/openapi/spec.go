@@ -0,0 +1,143 @@
+// Package openapi describes the HTTP API as an OpenAPI 3 document built
+// from the registered routes and the Go types they exchange.
+package openapi
+
+// Document is an OpenAPI 3.0 document
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem maps lower-case HTTP methods to operations
+type PathItem map[string]*OperationObject
+
+type OperationObject struct {
+	OperationID string                     `json:"operationId,omitempty"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Parameters  []*Param                   `json:"parameters,omitempty"`
+	RequestBody *RequestBody               `json:"requestBody,omitempty"`
+	Responses   map[string]*ResponseObject `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type ResponseObject struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// Schema is the subset of the OpenAPI schema object the generator emits
+// and the validator checks
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Maximum              *int               `json:"maximum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Param is a path or query parameter
+type Param struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+	// Explode is false for comma-separated lists
+	Explode *bool `json:"explode,omitempty"`
+}
+
+// Operation documents one route. Body and Response are zero values of the
+// Go types exchanged as JSON; their schemas are generated by reflection.
+type Operation struct {
+	Summary     string
+	Description string
+	Tag         string
+	Params      []*Param
+	Body        any
+	// Status is the success status, 200 if zero
+	Status int
+	// Response is nil when the success response has no body
+	Response any
+	// ContentType is the success content type when it is not JSON
+	ContentType string
+	// Auth marks routes that require an API key
+	Auth bool
+}
+
+// Query describes an optional query parameter
+func Query(name, description string, schema *Schema) *Param {
+	return &Param{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+// RequiredQuery describes a required query parameter
+func RequiredQuery(name, description string, schema *Schema) *Param {
+	return &Param{Name: name, In: "query", Description: description, Required: true, Schema: schema}
+}
+
+// Path describes a path parameter
+func Path(name, description string, schema *Schema) *Param {
+	return &Param{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+// List describes a comma-separated query parameter
+func List(name, description string, item *Schema) *Param {
+	explode := false
+	return &Param{Name: name, In: "query", Description: description, Schema: &Schema{Type: "array", Items: item}, Explode: &explode}
+}
+
+func String() *Schema  { return &Schema{Type: "string"} }
+func Integer() *Schema { return &Schema{Type: "integer"} }
+func Boolean() *Schema { return &Schema{Type: "boolean"} }
+func UUID() *Schema    { return &Schema{Type: "string", Format: "uuid"} }
+
+// Between is an integer schema with inclusive bounds
+func Between(min, max int) *Schema {
+	return &Schema{Type: "integer", Minimum: &min, Maximum: &max}
+}
+
+// Enum is a string schema accepting the given values
+func Enum[T ~string](values ...T) *Schema {
+	schema := &Schema{Type: "string"}
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, string(value))
+	}
+	return schema
+}
@@ -0,0 +1,192 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// validator checks decoded JSON values against schemas, resolving $refs
+// among the document's components
+type validator struct {
+	schemas map[string]*Schema
+}
+
+// decodeJSON decodes a body keeping numbers exact, so integers can be told
+// apart from other numbers
+func decodeJSON(body []byte) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// validate returns a message for every way value does not match schema.
+// path locates the value in messages, e.g. "body.items[0].name".
+func (v *validator) validate(schema *Schema, value any, path string) []string {
+	if schema == nil {
+		return nil
+	}
+	if value == nil {
+		if schema.Nullable || schema.isEmpty() {
+			return nil
+		}
+		if schema.Ref != "" {
+			return v.validate(v.resolve(schema), value, path)
+		}
+		return []string{path + ": must not be null"}
+	}
+	if schema.Ref != "" {
+		return v.validate(v.resolve(schema), value, path)
+	}
+
+	var problems []string
+	for _, part := range schema.AllOf {
+		problems = append(problems, v.validate(part, value, path)...)
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return append(problems, path+": must be an object")
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing property %q", path, name))
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, declared := schema.Properties[name]
+			switch {
+			case declared:
+				problems = append(problems, v.validate(property, object[name], path+"."+name)...)
+			case schema.AdditionalProperties != nil:
+				problems = append(problems, v.validate(schema.AdditionalProperties, object[name], path+"."+name)...)
+			case schema.Properties != nil:
+				problems = append(problems, fmt.Sprintf("%s: unexpected property %q", path, name))
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return append(problems, path+": must be an array")
+		}
+		for i, item := range array {
+			problems = append(problems, v.validate(schema.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return append(problems, path+": must be a string")
+		}
+		if problem := checkFormat(schema.Format, s); problem != "" {
+			problems = append(problems, path+": "+problem)
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok {
+			return append(problems, path+": must be an integer")
+		}
+		i, err := n.Int64()
+		if err != nil {
+			return append(problems, path+": must be an integer")
+		}
+		if schema.Minimum != nil && i < int64(*schema.Minimum) {
+			problems = append(problems, fmt.Sprintf("%s: must be at least %d", path, *schema.Minimum))
+		}
+		if schema.Maximum != nil && i > int64(*schema.Maximum) {
+			problems = append(problems, fmt.Sprintf("%s: must be at most %d", path, *schema.Maximum))
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return append(problems, path+": must be a number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return append(problems, path+": must be a boolean")
+		}
+	}
+
+	if len(schema.Enum) > 0 && !slices.ContainsFunc(schema.Enum, func(e any) bool { return fmt.Sprint(e) == fmt.Sprint(value) }) {
+		problems = append(problems, fmt.Sprintf("%s: %v is not one of %v", path, value, schema.Enum))
+	}
+	return problems
+}
+
+// validateParam checks a raw path or query parameter value
+func (v *validator) validateParam(param *Param, raw string) []string {
+	path := param.In + "." + param.Name
+	schema := v.resolve(param.Schema)
+	if schema.Type != "array" {
+		return v.validate(schema, paramValue(schema, raw), path)
+	}
+
+	var problems []string
+	for i, item := range strings.Split(raw, ",") {
+		item = strings.TrimSpace(item)
+		problems = append(problems, v.validate(schema.Items, paramValue(schema.Items, item), fmt.Sprintf("%s[%d]", path, i))...)
+	}
+	return problems
+}
+
+// paramValue converts a raw parameter to the JSON value its schema expects,
+// leaving it a string if it does not parse
+func paramValue(schema *Schema, raw string) any {
+	switch schema.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(raw, 64); err == nil {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	}
+	return raw
+}
+
+func (v *validator) resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = v.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+	}
+	return schema
+}
+
+func checkFormat(format, s string) string {
+	var err error
+	switch format {
+	case "uuid":
+		if !uuidPattern.MatchString(s) {
+			return "must be a UUID"
+		}
+	case "date":
+		_, err = time.Parse(time.DateOnly, s)
+	case "date-time":
+		_, err = time.Parse(time.RFC3339Nano, s)
+	}
+	if err != nil {
+		return "must be a " + format
+	}
+	return ""
+}
+
+// isEmpty reports whether the schema accepts any value
+func (s *Schema) isEmpty() bool {
+	return s.Ref == "" && s.Type == "" && len(s.AllOf) == 0 && len(s.Enum) == 0
+}
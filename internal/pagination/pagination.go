@@ -0,0 +1,163 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// DefaultLimit is the page size used when the client does not ask for one
+	DefaultLimit = 50
+	// MaxLimit caps the page size regardless of what the client asks for
+	MaxLimit = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last row of a page. Key holds the value of the primary
+// sort column and ID breaks ties, so ordering is stable across pages. Scope
+// names the list and sort order the cursor was issued for.
+type Cursor struct {
+	Scope string `json:"s"`
+	Key   string `json:"k"`
+	ID    string `json:"id,omitempty"`
+}
+
+// KeyKind is the type of a cursor's sort key
+type KeyKind int
+
+const (
+	TextKey KeyKind = iota
+	IntKey
+	TimeKey
+)
+
+// Scope describes the cursors of one list in one sort order, so a cursor
+// can be checked before it reaches a query
+type Scope struct {
+	Name string
+	Key  KeyKind
+	// HasID is set when rows are ordered by ID after the key
+	HasID bool
+}
+
+// Encode returns the opaque string handed to clients as next_cursor
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a cursor previously returned by Encode for a page of scope,
+// rejecting cursors issued for other lists or sort orders and keys or IDs
+// of the wrong type
+func (scope Scope) Decode(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if c.Scope != scope.Name || !scope.validKey(c.Key) || !scope.validID(c.ID) {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+func (scope Scope) validKey(key string) bool {
+	switch scope.Key {
+	case IntKey:
+		_, err := strconv.ParseInt(key, 10, 32)
+		return err == nil
+	case TimeKey:
+		// Postgres has no year zero
+		t, err := time.Parse(time.RFC3339Nano, key)
+		return err == nil && t.Year() > 0
+	default:
+		return utf8.ValidString(key) && !strings.ContainsRune(key, 0)
+	}
+}
+
+func (scope Scope) validID(id string) bool {
+	if !scope.HasID {
+		return id == ""
+	}
+	var uuid pgtype.UUID
+	return uuid.Scan(id) == nil
+}
+
+// Params describes the page a client asked for
+type Params struct {
+	Limit  int
+	Cursor *Cursor
+	// Scope is stamped on the cursors of the page
+	Scope Scope
+}
+
+// ParseParams reads limit and cursor query parameters for a list whose
+// cursors belong to scope, clamping the limit to MaxLimit
+func ParseParams(r *http.Request, scope Scope) (Params, error) {
+	params := Params{Limit: DefaultLimit, Scope: scope}
+
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			params.Limit = parsed
+		}
+	}
+	if params.Limit > MaxLimit {
+		params.Limit = MaxLimit
+	}
+
+	if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+		cursor, err := scope.Decode(cursorStr)
+		if err != nil {
+			return Params{}, err
+		}
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
+
+// Page is the response envelope for list endpoints
+type Page[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"next_cursor"`
+	TotalCount int64   `json:"total_count"`
+}
+
+// NewPage builds a page from rows fetched with a limit of params.Limit+1.
+// The extra row, if present, only signals that another page exists and is
+// dropped; cursorOf builds the cursor from the last row that is kept.
+func NewPage[T any](rows []T, total int64, params Params, cursorOf func(T) Cursor) Page[T] {
+	page := Page[T]{Items: rows, TotalCount: total}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if len(page.Items) > params.Limit {
+		page.Items = page.Items[:params.Limit]
+		cursor := cursorOf(page.Items[params.Limit-1])
+		cursor.Scope = params.Scope.Name
+		next := cursor.Encode()
+		page.NextCursor = &next
+	}
+	return page
+}
+
+// Map converts the items of a page, keeping its cursor and total
+func Map[T, U any](page Page[T], f func(T) U) Page[U] {
+	items := make([]U, len(page.Items))
+	for i, item := range page.Items {
+		items[i] = f(item)
+	}
+	return Page[U]{Items: items, NextCursor: page.NextCursor, TotalCount: page.TotalCount}
+}
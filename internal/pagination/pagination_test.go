@@ -0,0 +1,172 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+const testID = "7b1a0c2e-5d4f-4e3a-9b8c-1d2e3f4a5b6c"
+
+var (
+	byName  = Scope{Name: "celebrities:name", Key: TextKey, HasID: true}
+	byYear  = Scope{Name: "awards:year", Key: IntKey, HasID: true}
+	byTime  = Scope{Name: "events:created_at", Key: TimeKey, HasID: true}
+	byCount = Scope{Name: "stats:count", Key: IntKey}
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		scope  Scope
+		cursor Cursor
+	}{
+		{byName, Cursor{Key: "Beyoncé", ID: testID}},
+		{byYear, Cursor{Key: "1998", ID: testID}},
+		{byTime, Cursor{Key: "2024-02-04T01:02:03.456789Z", ID: testID}},
+		{byCount, Cursor{Key: "42"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope.Name, func(t *testing.T) {
+			tt.cursor.Scope = tt.scope.Name
+			got, err := tt.scope.Decode(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			if *got != tt.cursor {
+				t.Errorf("Decode = %+v, want %+v", *got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	encode := func(scope, key, id string) string {
+		return Cursor{Scope: scope, Key: key, ID: id}.Encode()
+	}
+
+	tests := []struct {
+		name   string
+		scope  Scope
+		cursor string
+	}{
+		{"another list's cursor", byName, encode(byYear.Name, "Beyoncé", testID)},
+		{"another sort order's cursor", byYear, encode("awards:name", "1998", testID)},
+		{"not base64", byName, "not a cursor!"},
+		{"not JSON", byName, base64.RawURLEncoding.EncodeToString([]byte("{"))},
+		{"text key with NUL", byName, encode(byName.Name, "a\x00b", testID)},
+		{"non-numeric int key", byYear, encode(byYear.Name, "1998; DROP TABLE awards", testID)},
+		{"int key out of range", byYear, encode(byYear.Name, "99999999999", testID)},
+		{"malformed time key", byTime, encode(byTime.Name, "yesterday", testID)},
+		{"time key in year zero", byTime, encode(byTime.Name, "0000-01-01T00:00:00Z", testID)},
+		{"malformed ID", byName, encode(byName.Name, "Beyoncé", "42")},
+		{"missing ID", byName, encode(byName.Name, "Beyoncé", "")},
+		{"unexpected ID", byCount, encode(byCount.Name, "42", testID)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.scope.Decode(tt.cursor); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("Decode = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	valid := Cursor{Scope: byYear.Name, Key: "1998", ID: testID}.Encode()
+
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantCursor bool
+		wantErr    bool
+	}{
+		{name: "defaults", query: "", wantLimit: DefaultLimit},
+		{name: "limit", query: "limit=10", wantLimit: 10},
+		{name: "limit clamped to MaxLimit", query: "limit=500", wantLimit: MaxLimit},
+		{name: "zero limit", query: "limit=0", wantLimit: DefaultLimit},
+		{name: "negative limit", query: "limit=-5", wantLimit: DefaultLimit},
+		{name: "non-numeric limit", query: "limit=ten", wantLimit: DefaultLimit},
+		{name: "cursor", query: "limit=10&cursor=" + valid, wantLimit: 10, wantCursor: true},
+		{name: "cursor from another scope", query: "cursor=" + Cursor{Scope: byName.Name, Key: "x", ID: testID}.Encode(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/awards?"+tt.query, nil)
+			params, err := ParseParams(r, byYear)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidCursor) {
+					t.Fatalf("ParseParams error = %v, want ErrInvalidCursor", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseParams: %v", err)
+			}
+			if params.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", params.Limit, tt.wantLimit)
+			}
+			if (params.Cursor != nil) != tt.wantCursor {
+				t.Errorf("Cursor = %+v, want present %v", params.Cursor, tt.wantCursor)
+			}
+			if params.Scope != byYear {
+				t.Errorf("Scope = %+v, want %+v", params.Scope, byYear)
+			}
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	params := Params{Limit: 3, Scope: byCount}
+	cursorOf := func(n int) Cursor { return Cursor{Key: strconv.Itoa(n)} }
+
+	tests := []struct {
+		name      string
+		rows      []int
+		wantItems []int
+		wantNext  string // key of the next cursor; empty on the last page
+	}{
+		{"more pages", []int{10, 20, 30, 40}, []int{10, 20, 30}, "30"},
+		{"exactly one page", []int{10, 20, 30}, []int{10, 20, 30}, ""},
+		{"short last page", []int{10}, []int{10}, ""},
+		{"no rows", nil, []int{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := NewPage(tt.rows, 7, params, cursorOf)
+
+			if page.Items == nil || !slices.Equal(page.Items, tt.wantItems) {
+				t.Errorf("Items = %#v, want %v", page.Items, tt.wantItems)
+			}
+			if page.TotalCount != 7 {
+				t.Errorf("TotalCount = %d, want 7", page.TotalCount)
+			}
+
+			if tt.wantNext == "" {
+				if page.NextCursor != nil {
+					t.Errorf("NextCursor = %q, want nil", *page.NextCursor)
+				}
+				return
+			}
+			if page.NextCursor == nil {
+				t.Fatal("NextCursor = nil, want a cursor")
+			}
+			// The cursor is stamped with the page's scope, so it is accepted
+			// for the next page
+			next, err := byCount.Decode(*page.NextCursor)
+			if err != nil {
+				t.Fatalf("Decode(NextCursor): %v", err)
+			}
+			if next.Key != tt.wantNext {
+				t.Errorf("NextCursor key = %q, want %q", next.Key, tt.wantNext)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrAliasExists = errors.New("alias already exists")
+
+// uniqueViolation is the Postgres error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+type AliasRepository struct {
+	db DBTX
+}
+
+func NewAliasRepository(db DBTX) *AliasRepository {
+	return &AliasRepository{db: db}
+}
+
+// FindByCelebrityID returns all aliases for a celebrity
+func (r *AliasRepository) FindByCelebrityID(ctx context.Context, celebrityID pgtype.UUID) ([]models.CelebrityAlias, error) {
+	query := `
+		SELECT id, celebrity_id, alias, source, created_at
+		FROM celebrity_aliases
+		WHERE celebrity_id = $1
+		ORDER BY alias
+	`
+
+	rows, err := r.db.Query(ctx, query, celebrityID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []models.CelebrityAlias
+	for rows.Next() {
+		var a models.CelebrityAlias
+		err := rows.Scan(&a.ID, &a.CelebrityID, &a.Alias, &a.Source, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+
+	return aliases, rows.Err()
+}
+
+// FindByCelebrityIDs returns the aliases of several celebrities in one query
+func (r *AliasRepository) FindByCelebrityIDs(ctx context.Context, celebrityIDs []pgtype.UUID) ([]models.CelebrityAlias, error) {
+	query := `
+		SELECT id, celebrity_id, alias, source, created_at
+		FROM celebrity_aliases
+		WHERE celebrity_id = ANY($1)
+		ORDER BY alias
+	`
+
+	rows, err := r.db.Query(ctx, query, celebrityIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var aliases []models.CelebrityAlias
+	for rows.Next() {
+		var a models.CelebrityAlias
+		err := rows.Scan(&a.ID, &a.CelebrityID, &a.Alias, &a.Source, &a.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, a)
+	}
+
+	return aliases, rows.Err()
+}
+
+// Create adds a single alias, returning ErrAliasExists if the celebrity
+// already has an alias that differs only in case or accents
+func (r *AliasRepository) Create(ctx context.Context, alias *models.CelebrityAlias) (*models.CelebrityAlias, error) {
+	query := `
+		INSERT INTO celebrity_aliases (celebrity_id, alias, source)
+		VALUES ($1, $2, $3)
+		RETURNING id, celebrity_id, alias, source, created_at
+	`
+
+	var created models.CelebrityAlias
+	err := r.db.QueryRow(ctx, query,
+		alias.CelebrityID,
+		strings.TrimSpace(alias.Alias),
+		alias.Source,
+	).Scan(
+		&created.ID,
+		&created.CelebrityID,
+		&created.Alias,
+		&created.Source,
+		&created.CreatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, ErrAliasExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// CreateBatch adds aliases for a celebrity in one round trip, skipping any
+// the celebrity already has
+func (r *AliasRepository) CreateBatch(ctx context.Context, celebrityID pgtype.UUID, aliases []models.CelebrityAlias) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO celebrity_aliases (celebrity_id, alias, source)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (celebrity_id, immutable_unaccent(LOWER(alias))) DO NOTHING
+	`
+
+	batch := &pgx.Batch{}
+	for _, alias := range aliases {
+		name := strings.TrimSpace(alias.Alias)
+		if name == "" {
+			continue
+		}
+		batch.Queue(query, celebrityID, name, alias.Source)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return r.db.SendBatch(ctx, batch).Close()
+}
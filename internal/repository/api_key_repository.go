@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+type APIKeyRepository struct {
+	db DBTX
+}
+
+func NewAPIKeyRepository(db DBTX) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create stores a new API key by the hash of its secret
+func (r *APIKeyRepository) Create(ctx context.Context, name, keyHash string, isAdmin bool) (*models.APIKey, error) {
+	query := `
+		INSERT INTO api_keys (name, key_hash, is_admin)
+		VALUES ($1, $2, $3)
+		RETURNING id, name, is_admin, created_at, revoked_at
+	`
+
+	var key models.APIKey
+	err := r.db.QueryRow(ctx, query, name, keyHash, isAdmin).Scan(
+		&key.ID,
+		&key.Name,
+		&key.IsAdmin,
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// FindActiveByHash returns the unrevoked API key with the given hash
+func (r *APIKeyRepository) FindActiveByHash(ctx context.Context, keyHash string) (*models.APIKey, error) {
+	query := `
+		SELECT id, name, is_admin, created_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL
+	`
+
+	var key models.APIKey
+	err := r.db.QueryRow(ctx, query, keyHash).Scan(
+		&key.ID,
+		&key.Name,
+		&key.IsAdmin,
+		&key.CreatedAt,
+		&key.RevokedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// Revoke marks an API key as revoked by name and returns how many keys were revoked
+func (r *APIKeyRepository) Revoke(ctx context.Context, name string) (int64, error) {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE api_keys SET revoked_at = NOW() WHERE name = $1 AND revoked_at IS NULL",
+		name,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
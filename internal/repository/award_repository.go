@@ -5,27 +5,46 @@ import (
 
 	"egot-tracker/internal/models"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type AwardRepository struct {
-	pool *pgxpool.Pool
+	db DBTX
 }
 
-func NewAwardRepository(pool *pgxpool.Pool) *AwardRepository {
-	return &AwardRepository{pool: pool}
+func NewAwardRepository(db DBTX) *AwardRepository {
+	return &AwardRepository{db: db}
 }
 
 func (r *AwardRepository) FindByCelebrityID(ctx context.Context, celebrityID pgtype.UUID) ([]models.Award, error) {
+	return r.findAll(ctx, "celebrity_id = $1", celebrityID)
+}
+
+// FindByCelebrityIDs returns the awards of several celebrities in one query
+func (r *AwardRepository) FindByCelebrityIDs(ctx context.Context, celebrityIDs []pgtype.UUID) ([]models.Award, error) {
+	return r.findAll(ctx, "celebrity_id = ANY($1)", celebrityIDs)
+}
+
+// FindByWorkIDs returns the awards given for several works in one query
+func (r *AwardRepository) FindByWorkIDs(ctx context.Context, workIDs []pgtype.UUID) ([]models.Award, error) {
+	return r.findAll(ctx, "work_id = ANY($1)", workIDs)
+}
+
+// findAll returns the awards matching where, newest first. Every award
+// finder that returns plain awards goes through it, so their columns are
+// listed and scanned in one place.
+func (r *AwardRepository) findAll(ctx context.Context, where string, args ...any) ([]models.Award, error) {
 	query := `
-		SELECT id, celebrity_id, type, year, work, category, is_winner, ceremony_date, is_upcoming
+		SELECT id, celebrity_id, type, year, work, category, is_winner, ceremony_date, is_upcoming, work_id,
+			source, wikidata_statement_id, wikidata_award_id, fetched_at, scraper_version, wikidata_work_id,
+			created_at, updated_at
 		FROM awards
-		WHERE celebrity_id = $1
+		WHERE ` + where + `
 		ORDER BY year DESC, type
 	`
 
-	rows, err := r.pool.Query(ctx, query, celebrityID)
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +63,15 @@ func (r *AwardRepository) FindByCelebrityID(ctx context.Context, celebrityID pgt
 			&award.IsWinner,
 			&award.CeremonyDate,
 			&award.IsUpcoming,
+			&award.WorkID,
+			&award.Source,
+			&award.WikidataStatementID,
+			&award.WikidataAwardID,
+			&award.FetchedAt,
+			&award.ScraperVersion,
+			&award.WikidataWorkID,
+			&award.CreatedAt,
+			&award.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -58,21 +86,78 @@ func (r *AwardRepository) FindByCelebrityID(ctx context.Context, celebrityID pgt
 	return awards, nil
 }
 
+// FindEGOTWinnerWins returns every past win of every celebrity who has won
+// all four EGOT awards
+func (r *AwardRepository) FindEGOTWinnerWins(ctx context.Context) ([]models.Award, error) {
+	return r.findAll(ctx, `
+		is_winner = true AND is_upcoming = false
+		AND celebrity_id IN (
+			SELECT celebrity_id
+			FROM awards
+			WHERE is_winner = true AND is_upcoming = false
+			GROUP BY celebrity_id
+			HAVING COUNT(DISTINCT type) = 4
+		)
+	`)
+}
+
+// CreateBatch upserts awards for a celebrity on the natural key
+// (celebrity, type, category, year, work), so re-running an import
+// refreshes existing rows instead of duplicating them. Awards are linked to
+// the work with their Wikidata work ID, which must already be saved. All rows
+// are sent to the server in a single round trip.
 func (r *AwardRepository) CreateBatch(ctx context.Context, celebrityID pgtype.UUID, awards []models.Award) ([]models.Award, error) {
 	if len(awards) == 0 {
 		return []models.Award{}, nil
 	}
 
 	query := `
-		INSERT INTO awards (celebrity_id, type, year, work, category, is_winner, ceremony_date, is_upcoming)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, celebrity_id, type, year, work, category, is_winner, ceremony_date, is_upcoming
+		INSERT INTO awards (
+			celebrity_id, type, year, work, category, is_winner, ceremony_date, is_upcoming,
+			source, wikidata_statement_id, wikidata_award_id, fetched_at, scraper_version,
+			wikidata_work_id, work_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+			$14, (SELECT id FROM works WHERE wikidata_id = $14))
+		ON CONFLICT (celebrity_id, type, category, year, work) DO UPDATE SET
+			is_winner = EXCLUDED.is_winner,
+			ceremony_date = EXCLUDED.ceremony_date,
+			is_upcoming = EXCLUDED.is_upcoming,
+			source = EXCLUDED.source,
+			wikidata_statement_id = COALESCE(EXCLUDED.wikidata_statement_id, awards.wikidata_statement_id),
+			wikidata_award_id = COALESCE(EXCLUDED.wikidata_award_id, awards.wikidata_award_id),
+			fetched_at = EXCLUDED.fetched_at,
+			scraper_version = EXCLUDED.scraper_version,
+			wikidata_work_id = COALESCE(EXCLUDED.wikidata_work_id, awards.wikidata_work_id),
+			work_id = COALESCE(EXCLUDED.work_id, awards.work_id),
+			updated_at = CASE
+				WHEN (awards.is_winner, awards.ceremony_date, awards.is_upcoming)
+					IS DISTINCT FROM (EXCLUDED.is_winner, EXCLUDED.ceremony_date, EXCLUDED.is_upcoming)
+				THEN NOW()
+				ELSE awards.updated_at
+			END
+		RETURNING id, celebrity_id, type, year, work, category, is_winner, ceremony_date, is_upcoming, work_id,
+			source, wikidata_statement_id, wikidata_award_id, fetched_at, scraper_version, wikidata_work_id,
+			created_at, updated_at
 	`
 
-	created := make([]models.Award, 0, len(awards))
+	batch := &pgx.Batch{}
+	seen := make(map[string]bool, len(awards))
 	for _, award := range awards {
-		var a models.Award
-		err := r.pool.QueryRow(ctx, query,
+		// An upsert cannot touch the same row twice, so skip duplicates
+		// within the batch itself
+		key := award.NaturalKey()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		source := award.Source
+		if source == "" {
+			source = models.AwardSourceManual
+		}
+
+		batch.Queue(query,
 			celebrityID,
 			award.Type,
 			award.Year,
@@ -81,7 +166,22 @@ func (r *AwardRepository) CreateBatch(ctx context.Context, celebrityID pgtype.UU
 			award.IsWinner,
 			award.CeremonyDate,
 			award.IsUpcoming,
-		).Scan(
+			source,
+			award.WikidataStatementID,
+			award.WikidataAwardID,
+			award.FetchedAt,
+			award.ScraperVersion,
+			award.WikidataWorkID,
+		)
+	}
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	created := make([]models.Award, 0, batch.Len())
+	for i := 0; i < batch.Len(); i++ {
+		var a models.Award
+		err := results.QueryRow().Scan(
 			&a.ID,
 			&a.CelebrityID,
 			&a.Type,
@@ -91,6 +191,15 @@ func (r *AwardRepository) CreateBatch(ctx context.Context, celebrityID pgtype.UU
 			&a.IsWinner,
 			&a.CeremonyDate,
 			&a.IsUpcoming,
+			&a.WorkID,
+			&a.Source,
+			&a.WikidataStatementID,
+			&a.WikidataAwardID,
+			&a.FetchedAt,
+			&a.ScraperVersion,
+			&a.WikidataWorkID,
+			&a.CreatedAt,
+			&a.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -98,5 +207,202 @@ func (r *AwardRepository) CreateBatch(ctx context.Context, celebrityID pgtype.UU
 		created = append(created, a)
 	}
 
-	return created, nil
+	return created, results.Close()
+}
+
+// FindRecentlyUpdated returns the limit most recently added or changed
+// awards with their recipients, newest first
+func (r *AwardRepository) FindRecentlyUpdated(ctx context.Context, limit int) ([]models.AwardWithCelebrity, error) {
+	query := `
+		SELECT a.id, a.celebrity_id, a.type, a.year, a.work, a.category, a.is_winner,
+			a.ceremony_date, a.is_upcoming, a.work_id, a.created_at, a.updated_at,
+			c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM awards a
+		INNER JOIN celebrities c ON c.id = a.celebrity_id
+		ORDER BY a.updated_at DESC, a.id
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var awards []models.AwardWithCelebrity
+	for rows.Next() {
+		var a models.AwardWithCelebrity
+		err := rows.Scan(
+			&a.ID,
+			&a.CelebrityID,
+			&a.Type,
+			&a.Year,
+			&a.Work,
+			&a.Category,
+			&a.IsWinner,
+			&a.CeremonyDate,
+			&a.IsUpcoming,
+			&a.WorkID,
+			&a.CreatedAt,
+			&a.UpdatedAt,
+			&a.Celebrity.ID,
+			&a.Celebrity.Name,
+			&a.Celebrity.Slug,
+			&a.Celebrity.PhotoURL,
+			&a.Celebrity.Summary,
+			&a.Celebrity.LastUpdated,
+			&a.Celebrity.DeathDate,
+		)
+		if err != nil {
+			return nil, err
+		}
+		awards = append(awards, a)
+	}
+
+	return awards, rows.Err()
+}
+
+// FindPendingNominations returns upcoming nominations for awards the nominee
+// has not won, where winning would take them to at least three of the four.
+// Nominations whose ceremony has passed are left out even before a rescrape
+// clears is_upcoming. Results are ordered by ceremony date (unknown dates last), then year,
+// award type and nominee name.
+func (r *AwardRepository) FindPendingNominations(ctx context.Context) ([]models.PendingNomination, error) {
+	query := `
+		WITH won AS (
+			SELECT celebrity_id, ARRAY_AGG(DISTINCT type::text ORDER BY type::text) AS won_awards
+			FROM awards
+			WHERE is_winner = true AND is_upcoming = false
+			GROUP BY celebrity_id
+		)
+		SELECT a.id, a.celebrity_id, a.type, a.year, a.work, a.category, a.is_winner,
+			a.ceremony_date, a.is_upcoming, a.work_id,
+			c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date,
+			CARDINALITY(won.won_awards), won.won_awards
+		FROM awards a
+		INNER JOIN celebrities c ON c.id = a.celebrity_id
+		INNER JOIN won ON won.celebrity_id = a.celebrity_id
+		WHERE a.is_upcoming = true
+		  AND (a.ceremony_date IS NULL OR a.ceremony_date >= CURRENT_DATE)
+		  AND NOT (a.type::text = ANY(won.won_awards))
+		  AND CARDINALITY(won.won_awards) >= 2
+		ORDER BY a.ceremony_date NULLS LAST, a.year, a.type, c.name, a.category
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nominations []models.PendingNomination
+	for rows.Next() {
+		var n models.PendingNomination
+		err := rows.Scan(
+			&n.Award.ID,
+			&n.Award.CelebrityID,
+			&n.Award.Type,
+			&n.Award.Year,
+			&n.Award.Work,
+			&n.Award.Category,
+			&n.Award.IsWinner,
+			&n.Award.CeremonyDate,
+			&n.Award.IsUpcoming,
+			&n.Award.WorkID,
+			&n.Celebrity.ID,
+			&n.Celebrity.Name,
+			&n.Celebrity.Slug,
+			&n.Celebrity.PhotoURL,
+			&n.Celebrity.Summary,
+			&n.Celebrity.LastUpdated,
+			&n.Celebrity.DeathDate,
+			&n.Celebrity.EGOTWinCount,
+			&n.Celebrity.WonAwards,
+		)
+		if err != nil {
+			return nil, err
+		}
+		n.CompletesEGOT = n.Celebrity.EGOTWinCount == 3
+		nominations = append(nominations, n)
+	}
+
+	return nominations, rows.Err()
+}
+
+// FindStatementsWithoutWork returns the Wikidata statement IDs of awards
+// whose work has not been resolved
+func (r *AwardRepository) FindStatementsWithoutWork(ctx context.Context) ([]string, error) {
+	query := `
+		SELECT DISTINCT wikidata_statement_id
+		FROM awards
+		WHERE wikidata_statement_id IS NOT NULL AND wikidata_work_id IS NULL
+		ORDER BY wikidata_statement_id
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var statementIDs []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		statementIDs = append(statementIDs, id)
+	}
+
+	return statementIDs, rows.Err()
+}
+
+// SetWikidataWorkIDs records the Wikidata work ID of each award statement,
+// keyed by statement ID, in one round trip
+func (r *AwardRepository) SetWikidataWorkIDs(ctx context.Context, workIDs map[string]string) error {
+	if len(workIDs) == 0 {
+		return nil
+	}
+
+	query := `UPDATE awards SET wikidata_work_id = $2 WHERE wikidata_statement_id = $1`
+
+	batch := &pgx.Batch{}
+	for statementID, workID := range workIDs {
+		batch.Queue(query, statementID, workID)
+	}
+
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// duplicateAwardsCTE ranks rows sharing a natural key so that the row to keep
+// (a winner, with a Wikidata statement, most recently fetched) comes first
+const duplicateAwardsCTE = `
+	WITH ranked AS (
+		SELECT id, ROW_NUMBER() OVER (
+			PARTITION BY celebrity_id, type, category, year, work
+			ORDER BY is_winner DESC, (wikidata_statement_id IS NULL), fetched_at DESC NULLS LAST, id
+		) AS rn
+		FROM awards
+	)
+`
+
+// CountDuplicates returns how many award rows duplicate another row's natural key
+func (r *AwardRepository) CountDuplicates(ctx context.Context) (int64, error) {
+	query := duplicateAwardsCTE + `SELECT COUNT(*) FROM ranked WHERE rn > 1`
+
+	var count int64
+	err := r.db.QueryRow(ctx, query).Scan(&count)
+	return count, err
+}
+
+// DeleteDuplicates removes all but one row for every natural key and
+// returns the number of rows deleted
+func (r *AwardRepository) DeleteDuplicates(ctx context.Context) (int64, error) {
+	query := duplicateAwardsCTE + `DELETE FROM awards WHERE id IN (SELECT id FROM ranked WHERE rn > 1)`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
 }
@@ -3,40 +3,97 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var ErrCelebrityNotFound = errors.New("celebrity not found")
+var (
+	ErrCelebrityNotFound = errors.New("celebrity not found")
+	ErrUnknownMetric     = errors.New("unknown leaderboard metric")
+)
 
 type CelebrityRepository struct {
-	pool *pgxpool.Pool
+	db DBTX
 }
 
-func NewCelebrityRepository(pool *pgxpool.Pool) *CelebrityRepository {
-	return &CelebrityRepository{pool: pool}
+func NewCelebrityRepository(db DBTX) *CelebrityRepository {
+	return &CelebrityRepository{db: db}
 }
 
+// FindByName looks a celebrity up by name or alias, ignoring case and
+// accents. Only exact matches count: a similar name may well be someone not
+// tracked yet, who should be scraped rather than confused with an existing
+// celebrity. Fuzzy matching is left to Search and Autocomplete.
 func (r *CelebrityRepository) FindByName(ctx context.Context, name string) (*models.Celebrity, error) {
 	query := `
-		SELECT id, name, slug, photo_url, summary, last_updated
+		WITH q AS (
+			SELECT immutable_unaccent(LOWER($1)) AS term
+		),
+		matches AS (
+			SELECT c.id AS celebrity_id, true AS is_name
+			FROM celebrities c, q
+			WHERE immutable_unaccent(LOWER(c.name)) = q.term
+			UNION ALL
+			SELECT ca.celebrity_id, false
+			FROM celebrity_aliases ca, q
+			WHERE immutable_unaccent(LOWER(ca.alias)) = q.term
+		)
+		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM matches m
+		INNER JOIN celebrities c ON c.id = m.celebrity_id
+		ORDER BY
+			m.is_name DESC,
+			(SELECT COUNT(DISTINCT a.type) FROM awards a
+			 WHERE a.celebrity_id = c.id AND a.is_winner = true AND a.is_upcoming = false) DESC
+		LIMIT 1
+	`
+
+	var celebrity models.Celebrity
+	err := r.db.QueryRow(ctx, query, strings.TrimSpace(name)).Scan(
+		&celebrity.ID,
+		&celebrity.Name,
+		&celebrity.Slug,
+		&celebrity.PhotoURL,
+		&celebrity.Summary,
+		&celebrity.LastUpdated,
+		&celebrity.DeathDate,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCelebrityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &celebrity, nil
+}
+
+// FindByExactName looks a celebrity up by their stored name only, ignoring
+// case. Unlike FindByName it never matches aliases or similar names.
+func (r *CelebrityRepository) FindByExactName(ctx context.Context, name string) (*models.Celebrity, error) {
+	query := `
+		SELECT id, name, slug, photo_url, summary, last_updated, death_date
 		FROM celebrities
 		WHERE LOWER(name) = LOWER($1)
 	`
 
 	var celebrity models.Celebrity
-	err := r.pool.QueryRow(ctx, query, strings.TrimSpace(name)).Scan(
+	err := r.db.QueryRow(ctx, query, strings.TrimSpace(name)).Scan(
 		&celebrity.ID,
 		&celebrity.Name,
 		&celebrity.Slug,
 		&celebrity.PhotoURL,
 		&celebrity.Summary,
 		&celebrity.LastUpdated,
+		&celebrity.DeathDate,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -51,19 +108,20 @@ func (r *CelebrityRepository) FindByName(ctx context.Context, name string) (*mod
 
 func (r *CelebrityRepository) FindByID(ctx context.Context, id pgtype.UUID) (*models.Celebrity, error) {
 	query := `
-		SELECT id, name, slug, photo_url, summary, last_updated
+		SELECT id, name, slug, photo_url, summary, last_updated, death_date
 		FROM celebrities
 		WHERE id = $1
 	`
 
 	var celebrity models.Celebrity
-	err := r.pool.QueryRow(ctx, query, id).Scan(
+	err := r.db.QueryRow(ctx, query, id).Scan(
 		&celebrity.ID,
 		&celebrity.Name,
 		&celebrity.Slug,
 		&celebrity.PhotoURL,
 		&celebrity.Summary,
 		&celebrity.LastUpdated,
+		&celebrity.DeathDate,
 	)
 
 	if errors.Is(err, pgx.ErrNoRows) {
@@ -76,16 +134,15 @@ func (r *CelebrityRepository) FindByID(ctx context.Context, id pgtype.UUID) (*mo
 	return &celebrity, nil
 }
 
-func (r *CelebrityRepository) Search(ctx context.Context, query string, limit int) ([]models.Celebrity, error) {
-	sql := `
-		SELECT id, name, slug, photo_url, summary, last_updated
+// FindByIDs returns the celebrities with the given IDs in one query
+func (r *CelebrityRepository) FindByIDs(ctx context.Context, ids []pgtype.UUID) ([]models.Celebrity, error) {
+	query := `
+		SELECT id, name, slug, photo_url, summary, last_updated, death_date
 		FROM celebrities
-		WHERE LOWER(name) LIKE LOWER($1)
-		ORDER BY name
-		LIMIT $2
+		WHERE id = ANY($1)
 	`
 
-	rows, err := r.pool.Query(ctx, sql, "%"+strings.TrimSpace(query)+"%", limit)
+	rows, err := r.db.Query(ctx, query, ids)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +151,7 @@ func (r *CelebrityRepository) Search(ctx context.Context, query string, limit in
 	var celebrities []models.Celebrity
 	for rows.Next() {
 		var c models.Celebrity
-		err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.PhotoURL, &c.Summary, &c.LastUpdated)
+		err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.PhotoURL, &c.Summary, &c.LastUpdated, &c.DeathDate)
 		if err != nil {
 			return nil, err
 		}
@@ -104,103 +161,104 @@ func (r *CelebrityRepository) Search(ctx context.Context, query string, limit in
 	return celebrities, rows.Err()
 }
 
-// FindCloseToEGOT returns celebrities with exactly 3 unique EGOT award wins
-func (r *CelebrityRepository) FindCloseToEGOT(ctx context.Context, limit int) ([]models.CelebrityWithEGOTProgress, error) {
+// FindBySlug looks a celebrity up by slug. Slugs are not unique, so the most
+// recently updated match wins.
+func (r *CelebrityRepository) FindBySlug(ctx context.Context, slug string) (*models.Celebrity, error) {
 	query := `
-		WITH celebrity_wins AS (
-			SELECT
-				c.id,
-				c.name,
-				c.slug,
-				c.photo_url,
-				c.summary,
-				c.last_updated,
-				COUNT(DISTINCT a.type) as egot_win_count,
-				ARRAY_AGG(DISTINCT a.type::text ORDER BY a.type::text) as won_awards
-			FROM celebrities c
-			INNER JOIN awards a ON c.id = a.celebrity_id
-			WHERE a.is_winner = true AND a.is_upcoming = false
-			GROUP BY c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated
-			HAVING COUNT(DISTINCT a.type) = 3
-		)
-		SELECT id, name, slug, photo_url, summary, last_updated, egot_win_count, won_awards
-		FROM celebrity_wins
-		ORDER BY name
-		LIMIT $1
+		SELECT id, name, slug, photo_url, summary, last_updated, death_date
+		FROM celebrities
+		WHERE slug = $1
+		ORDER BY last_updated DESC, id
+		LIMIT 1
+	`
+
+	var celebrity models.Celebrity
+	err := r.db.QueryRow(ctx, query, slug).Scan(
+		&celebrity.ID,
+		&celebrity.Name,
+		&celebrity.Slug,
+		&celebrity.PhotoURL,
+		&celebrity.Summary,
+		&celebrity.LastUpdated,
+		&celebrity.DeathDate,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCelebrityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &celebrity, nil
+}
+
+// FindEGOTWinners returns every celebrity who has won all four EGOT awards
+func (r *CelebrityRepository) FindEGOTWinners(ctx context.Context) ([]models.Celebrity, error) {
+	query := `
+		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM celebrities c
+		INNER JOIN awards a ON c.id = a.celebrity_id
+		WHERE a.is_winner = true AND a.is_upcoming = false
+		GROUP BY c.id
+		HAVING COUNT(DISTINCT a.type) = 4
+		ORDER BY c.name
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit)
+	rows, err := r.db.Query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var celebrities []models.CelebrityWithEGOTProgress
+	var celebrities []models.Celebrity
 	for rows.Next() {
-		var c models.CelebrityWithEGOTProgress
+		var celebrity models.Celebrity
 		err := rows.Scan(
-			&c.ID,
-			&c.Name,
-			&c.Slug,
-			&c.PhotoURL,
-			&c.Summary,
-			&c.LastUpdated,
-			&c.EGOTWinCount,
-			&c.WonAwards,
+			&celebrity.ID,
+			&celebrity.Name,
+			&celebrity.Slug,
+			&celebrity.PhotoURL,
+			&celebrity.Summary,
+			&celebrity.LastUpdated,
+			&celebrity.DeathDate,
 		)
 		if err != nil {
 			return nil, err
 		}
-		celebrities = append(celebrities, c)
+		celebrities = append(celebrities, celebrity)
 	}
 
 	return celebrities, rows.Err()
 }
 
-// FindEGOTWinners returns celebrities with all 4 unique EGOT award wins
-func (r *CelebrityRepository) FindEGOTWinners(ctx context.Context, limit int) ([]models.CelebrityWithEGOTProgress, error) {
+// FindDueForRefresh returns up to limit celebrities due to be scraped again:
+// those on a watchlist last updated before watchedBefore, and the rest last
+// updated before staleBefore. Watched celebrities come first, then the least
+// recently updated.
+func (r *CelebrityRepository) FindDueForRefresh(ctx context.Context, watchedBefore, staleBefore time.Time, limit int) ([]models.Celebrity, error) {
 	query := `
-		WITH celebrity_wins AS (
-			SELECT
-				c.id,
-				c.name,
-				c.slug,
-				c.photo_url,
-				c.summary,
-				c.last_updated,
-				COUNT(DISTINCT a.type) as egot_win_count,
-				ARRAY_AGG(DISTINCT a.type::text ORDER BY a.type::text) as won_awards
-			FROM celebrities c
-			INNER JOIN awards a ON c.id = a.celebrity_id
-			WHERE a.is_winner = true AND a.is_upcoming = false
-			GROUP BY c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated
-			HAVING COUNT(DISTINCT a.type) = 4
-		)
-		SELECT id, name, slug, photo_url, summary, last_updated, egot_win_count, won_awards
-		FROM celebrity_wins
-		ORDER BY name
-		LIMIT $1
+		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM celebrities c
+		CROSS JOIN LATERAL (
+			SELECT EXISTS (SELECT 1 FROM watchlist_celebrities wc WHERE wc.celebrity_id = c.id) AS watched
+		) w
+		WHERE c.last_updated IS NULL
+		   OR c.last_updated < CASE WHEN w.watched THEN $1::timestamptz ELSE $2::timestamptz END
+		ORDER BY w.watched DESC, c.last_updated NULLS FIRST, c.id
+		LIMIT $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit)
+	rows, err := r.db.Query(ctx, query, watchedBefore, staleBefore, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var celebrities []models.CelebrityWithEGOTProgress
+	var celebrities []models.Celebrity
 	for rows.Next() {
-		var c models.CelebrityWithEGOTProgress
-		err := rows.Scan(
-			&c.ID,
-			&c.Name,
-			&c.Slug,
-			&c.PhotoURL,
-			&c.Summary,
-			&c.LastUpdated,
-			&c.EGOTWinCount,
-			&c.WonAwards,
-		)
+		var c models.Celebrity
+		err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.PhotoURL, &c.Summary, &c.LastUpdated, &c.DeathDate)
 		if err != nil {
 			return nil, err
 		}
@@ -210,18 +268,54 @@ func (r *CelebrityRepository) FindEGOTWinners(ctx context.Context, limit int) ([
 	return celebrities, rows.Err()
 }
 
-// FindNoAwards returns celebrities with no awards
-func (r *CelebrityRepository) FindNoAwards(ctx context.Context, limit int) ([]models.Celebrity, error) {
-	query := `
-		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated
-		FROM celebrities c
-		LEFT JOIN awards a ON c.id = a.celebrity_id
-		WHERE a.id IS NULL
-		ORDER BY c.last_updated ASC
-		LIMIT $1
+// Touch marks a celebrity as updated now
+func (r *CelebrityRepository) Touch(ctx context.Context, id pgtype.UUID) error {
+	_, err := r.db.Exec(ctx, `UPDATE celebrities SET last_updated = NOW() WHERE id = $1`, id)
+	return err
+}
+
+// Search returns celebrities whose names or aliases fuzzily match the query,
+// ignoring case and accents. Results are ranked with prefix matches (on the
+// full name or any word in it) first, then by trigram similarity, then by
+// EGOT progress.
+func (r *CelebrityRepository) Search(ctx context.Context, query string, limit int) ([]models.Celebrity, error) {
+	sql := `
+		WITH q AS (
+			SELECT immutable_unaccent(LOWER($1)) AS term, immutable_unaccent(LOWER($2)) AS pattern
+		),
+		matches AS (
+			SELECT c.id AS celebrity_id, immutable_unaccent(LOWER(c.name)) AS matched
+			FROM celebrities c, q
+			WHERE immutable_unaccent(LOWER(c.name)) LIKE '%' || q.pattern || '%'
+			   OR immutable_unaccent(LOWER(c.name)) % q.term
+			UNION ALL
+			SELECT ca.celebrity_id, immutable_unaccent(LOWER(ca.alias))
+			FROM celebrity_aliases ca, q
+			WHERE immutable_unaccent(LOWER(ca.alias)) LIKE '%' || q.pattern || '%'
+			   OR immutable_unaccent(LOWER(ca.alias)) % q.term
+		),
+		ranked AS (
+			SELECT
+				m.celebrity_id,
+				BOOL_OR(m.matched LIKE q.pattern || '%' OR m.matched LIKE '% ' || q.pattern || '%') AS is_prefix,
+				MAX(similarity(m.matched, q.term)) AS score
+			FROM matches m, q
+			GROUP BY m.celebrity_id
+		)
+		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM ranked r
+		INNER JOIN celebrities c ON c.id = r.celebrity_id
+		ORDER BY
+			r.is_prefix DESC,
+			r.score DESC,
+			(SELECT COUNT(DISTINCT a.type) FROM awards a
+			 WHERE a.celebrity_id = c.id AND a.is_winner = true AND a.is_upcoming = false) DESC,
+			c.name
+		LIMIT $3
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit)
+	query = strings.TrimSpace(query)
+	rows, err := r.db.Query(ctx, sql, query, escapeLike(query), limit)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +324,7 @@ func (r *CelebrityRepository) FindNoAwards(ctx context.Context, limit int) ([]mo
 	var celebrities []models.Celebrity
 	for rows.Next() {
 		var c models.Celebrity
-		err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.PhotoURL, &c.Summary, &c.LastUpdated)
+		err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.PhotoURL, &c.Summary, &c.LastUpdated, &c.DeathDate)
 		if err != nil {
 			return nil, err
 		}
@@ -240,19 +334,310 @@ func (r *CelebrityRepository) FindNoAwards(ctx context.Context, limit int) ([]mo
 	return celebrities, rows.Err()
 }
 
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "%", `\%`)
+	s = strings.ReplaceAll(s, "_", `\_`)
+	return s
+}
+
+// celebrityStatsCTE computes per-celebrity EGOT statistics that browse
+// filters and sorts on
+const celebrityStatsCTE = `
+	stats AS (
+		SELECT
+			c.id,
+			c.name,
+			c.slug,
+			c.photo_url,
+			c.summary,
+			c.last_updated,
+			c.death_date,
+			COUNT(DISTINCT a.type) FILTER (WHERE a.is_winner AND NOT a.is_upcoming) AS egot_win_count,
+			COALESCE(
+				ARRAY_AGG(DISTINCT a.type::text ORDER BY a.type::text) FILTER (WHERE a.is_winner AND NOT a.is_upcoming),
+				'{}'
+			) AS won_awards,
+			COUNT(a.id) FILTER (WHERE a.is_winner AND NOT a.is_upcoming) AS total_wins,
+			MAX(a.year) FILTER (WHERE a.is_winner AND NOT a.is_upcoming) AS latest_win_year,
+			MIN(a.year) FILTER (WHERE a.is_winner AND NOT a.is_upcoming AND a.year > 0) AS first_win_year,
+			COUNT(a.id) FILTER (WHERE a.is_winner AND NOT a.is_upcoming AND a.type = 'Emmy') AS emmy_wins,
+			COUNT(a.id) FILTER (WHERE a.is_winner AND NOT a.is_upcoming AND a.type = 'Grammy') AS grammy_wins,
+			COUNT(a.id) FILTER (WHERE a.is_winner AND NOT a.is_upcoming AND a.type = 'Oscar') AS oscar_wins,
+			COUNT(a.id) FILTER (WHERE a.is_winner AND NOT a.is_upcoming AND a.type = 'Tony') AS tony_wins,
+			COUNT(a.id) AS award_count
+		FROM celebrities c
+		LEFT JOIN awards a ON c.id = a.celebrity_id
+		GROUP BY c.id
+	)
+`
+
+// browseWhere builds the WHERE clause for a filter over the stats CTE,
+// appending its arguments to args
+func browseWhere(filter models.CelebrityFilter, args []any) (string, []any) {
+	conditions := []string{"TRUE"}
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.MinWins != nil {
+		conditions = append(conditions, "egot_win_count >= "+arg(*filter.MinWins))
+	}
+	if filter.MaxWins != nil {
+		conditions = append(conditions, "egot_win_count <= "+arg(*filter.MaxWins))
+	}
+	if len(filter.Missing) > 0 {
+		missing := make([]string, len(filter.Missing))
+		for i, t := range filter.Missing {
+			missing[i] = string(t)
+		}
+		conditions = append(conditions, "NOT (won_awards && "+arg(missing)+"::text[])")
+	}
+	if filter.WonFrom != nil || filter.WonTo != nil {
+		cond := "EXISTS (SELECT 1 FROM awards w WHERE w.celebrity_id = stats.id AND w.is_winner AND NOT w.is_upcoming"
+		if filter.WonFrom != nil {
+			cond += " AND w.year >= " + arg(*filter.WonFrom)
+		}
+		if filter.WonTo != nil {
+			cond += " AND w.year <= " + arg(*filter.WonTo)
+		}
+		conditions = append(conditions, cond+")")
+	}
+	switch filter.Status {
+	case models.LifeStatusLiving:
+		conditions = append(conditions, "death_date IS NULL")
+	case models.LifeStatusDeceased:
+		conditions = append(conditions, "death_date IS NOT NULL")
+	}
+	if filter.HasAwards != nil {
+		if *filter.HasAwards {
+			conditions = append(conditions, "award_count > 0")
+		} else {
+			conditions = append(conditions, "award_count = 0")
+		}
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// browseOrder returns the ORDER BY clause for a sort and the keyset
+// condition that selects rows after the cursor
+func browseOrder(sort models.CelebritySort, cursorKey, cursorID string) (string, string) {
+	switch sort {
+	case models.SortByRecentWin:
+		return "COALESCE(latest_win_year, 0) DESC, id",
+			"(COALESCE(latest_win_year, 0) < " + cursorKey + "::text::int OR (COALESCE(latest_win_year, 0) = " + cursorKey + "::text::int AND id > " + cursorID + "::text::uuid))"
+	case models.SortByTotalWins:
+		return "total_wins DESC, id",
+			"(total_wins < " + cursorKey + "::text::int OR (total_wins = " + cursorKey + "::text::int AND id > " + cursorID + "::text::uuid))"
+	case models.SortByUpdated:
+		return "COALESCE(last_updated, 'epoch') ASC, id",
+			"(COALESCE(last_updated, 'epoch'), id) > (" + cursorKey + "::text::timestamptz, " + cursorID + "::text::uuid)"
+	default:
+		return "name, id",
+			"(name, id) > (" + cursorKey + "::text, " + cursorID + "::text::uuid)"
+	}
+}
+
+// Browse returns a page of celebrities matching the filter in the filter's
+// sort order, along with the total number of matches
+func (r *CelebrityRepository) Browse(ctx context.Context, filter models.CelebrityFilter, page pagination.Params) ([]models.CelebrityBrowseItem, int64, error) {
+	args := []any{page.Limit + 1}
+	where, args := browseWhere(filter, args)
+
+	cursorKey, cursorID := cursorArgs(page.Cursor)
+	args = append(args, cursorKey, cursorID)
+	keyParam, idParam := fmt.Sprintf("$%d", len(args)-1), fmt.Sprintf("$%d", len(args))
+	orderBy, after := browseOrder(filter.Sort, keyParam, idParam)
+
+	query := `
+		WITH ` + celebrityStatsCTE + `
+		SELECT id, name, slug, photo_url, summary, last_updated, death_date,
+			egot_win_count, won_awards, total_wins, latest_win_year
+		FROM stats
+		WHERE (` + where + `)
+		  AND (` + keyParam + `::text IS NULL OR ` + after + `)
+		ORDER BY ` + orderBy + `
+		LIMIT $1
+	`
+
+	countWhere, countArgs := browseWhere(filter, nil)
+	countQuery := `
+		WITH ` + celebrityStatsCTE + `
+		SELECT COUNT(*) FROM stats WHERE ` + countWhere
+
+	return queryPage(ctx, r.db, sqlQuery{query, args}, sqlQuery{countQuery, countArgs},
+		func(row pgx.CollectableRow) (models.CelebrityBrowseItem, error) {
+			var c models.CelebrityBrowseItem
+			err := row.Scan(
+				&c.ID,
+				&c.Name,
+				&c.Slug,
+				&c.PhotoURL,
+				&c.Summary,
+				&c.LastUpdated,
+				&c.DeathDate,
+				&c.EGOTWinCount,
+				&c.WonAwards,
+				&c.TotalWins,
+				&c.LatestWinYear,
+			)
+			return c, err
+		})
+}
+
+// leaderboardValue returns the SQL expression over the stats CTE that a
+// leaderboard metric ranks by
+func leaderboardValue(metric models.LeaderboardMetric) (string, bool) {
+	switch metric {
+	case models.MetricEmmys:
+		return "emmy_wins", true
+	case models.MetricGrammys:
+		return "grammy_wins", true
+	case models.MetricOscars:
+		return "oscar_wins", true
+	case models.MetricTonys:
+		return "tony_wins", true
+	case models.MetricTotalWins:
+		return "total_wins", true
+	case models.MetricNominations:
+		return "award_count", true
+	case models.MetricWinSpan:
+		return "COALESCE(latest_win_year - first_win_year, 0)", true
+	default:
+		return "", false
+	}
+}
+
+// Leaderboard returns a page of the celebrities matching the filter ranked by
+// the metric, highest first, along with the number of ranked celebrities.
+// Celebrities scoring zero are left off the board. Ties share a rank.
+func (r *CelebrityRepository) Leaderboard(ctx context.Context, metric models.LeaderboardMetric, filter models.CelebrityFilter, page pagination.Params) ([]models.LeaderboardEntry, int64, error) {
+	value, ok := leaderboardValue(metric)
+	if !ok {
+		return nil, 0, ErrUnknownMetric
+	}
+
+	args := []any{page.Limit + 1}
+	where, args := browseWhere(filter, args)
+
+	cursorKey, cursorID := cursorArgs(page.Cursor)
+	args = append(args, cursorKey, cursorID)
+	keyParam, idParam := fmt.Sprintf("$%d", len(args)-1), fmt.Sprintf("$%d", len(args))
+
+	query := `
+		WITH ` + celebrityStatsCTE + `,
+		ranked AS (
+			SELECT *, ` + value + ` AS value, RANK() OVER (ORDER BY ` + value + ` DESC) AS rank
+			FROM stats
+			WHERE ` + where + ` AND ` + value + ` > 0
+		)
+		SELECT id, name, slug, photo_url, summary, last_updated, death_date,
+			egot_win_count, won_awards, value, rank
+		FROM ranked
+		WHERE ` + keyParam + `::text IS NULL
+		   OR value < ` + keyParam + `::text::int
+		   OR (value = ` + keyParam + `::text::int AND id > ` + idParam + `::text::uuid)
+		ORDER BY value DESC, id
+		LIMIT $1
+	`
+
+	countWhere, countArgs := browseWhere(filter, nil)
+	countQuery := `
+		WITH ` + celebrityStatsCTE + `
+		SELECT COUNT(*) FROM stats WHERE ` + countWhere + ` AND ` + value + ` > 0`
+
+	return queryPage(ctx, r.db, sqlQuery{query, args}, sqlQuery{countQuery, countArgs},
+		func(row pgx.CollectableRow) (models.LeaderboardEntry, error) {
+			var e models.LeaderboardEntry
+			err := row.Scan(
+				&e.ID,
+				&e.Name,
+				&e.Slug,
+				&e.PhotoURL,
+				&e.Summary,
+				&e.LastUpdated,
+				&e.DeathDate,
+				&e.EGOTWinCount,
+				&e.WonAwards,
+				&e.Value,
+				&e.Rank,
+			)
+			return e, err
+		})
+}
+
+// Facets counts the celebrities matching the filter by EGOT win count,
+// by each award they are missing, and by living/deceased status
+func (r *CelebrityRepository) Facets(ctx context.Context, filter models.CelebrityFilter) (*models.CelebrityFacets, error) {
+	where, args := browseWhere(filter, nil)
+
+	query := `
+		WITH ` + celebrityStatsCTE + `,
+		filtered AS (
+			SELECT * FROM stats WHERE ` + where + `
+		)
+		SELECT
+			COUNT(*) FILTER (WHERE egot_win_count = 0),
+			COUNT(*) FILTER (WHERE egot_win_count = 1),
+			COUNT(*) FILTER (WHERE egot_win_count = 2),
+			COUNT(*) FILTER (WHERE egot_win_count = 3),
+			COUNT(*) FILTER (WHERE egot_win_count = 4),
+			COUNT(*) FILTER (WHERE NOT ('Emmy' = ANY(won_awards))),
+			COUNT(*) FILTER (WHERE NOT ('Grammy' = ANY(won_awards))),
+			COUNT(*) FILTER (WHERE NOT ('Oscar' = ANY(won_awards))),
+			COUNT(*) FILTER (WHERE NOT ('Tony' = ANY(won_awards))),
+			COUNT(*) FILTER (WHERE death_date IS NULL),
+			COUNT(*) FILTER (WHERE death_date IS NOT NULL)
+		FROM filtered
+	`
+
+	var wins [5]int64
+	var missing [4]int64
+	var living, deceased int64
+	err := r.db.QueryRow(ctx, query, args...).Scan(
+		&wins[0], &wins[1], &wins[2], &wins[3], &wins[4],
+		&missing[0], &missing[1], &missing[2], &missing[3],
+		&living, &deceased,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &models.CelebrityFacets{
+		WinCount: make(map[int]int64, len(wins)),
+		Missing:  make(map[models.AwardType]int64, len(missing)),
+		Status: map[models.LifeStatus]int64{
+			models.LifeStatusLiving:   living,
+			models.LifeStatusDeceased: deceased,
+		},
+	}
+	for i, count := range wins {
+		facets.WinCount[i] = count
+	}
+	for i, awardType := range models.AllAwardTypes {
+		facets.Missing[awardType] = missing[i]
+	}
+
+	return facets, nil
+}
+
 func (r *CelebrityRepository) Create(ctx context.Context, celebrity *models.Celebrity) (*models.Celebrity, error) {
 	query := `
-		INSERT INTO celebrities (name, slug, photo_url, summary)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, name, slug, photo_url, summary, last_updated
+		INSERT INTO celebrities (name, slug, photo_url, summary, death_date)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, name, slug, photo_url, summary, last_updated, death_date
 	`
 
 	var created models.Celebrity
-	err := r.pool.QueryRow(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		celebrity.Name,
 		celebrity.Slug,
 		celebrity.PhotoURL,
 		celebrity.Summary,
+		celebrity.DeathDate,
 	).Scan(
 		&created.ID,
 		&created.Name,
@@ -260,6 +645,7 @@ func (r *CelebrityRepository) Create(ctx context.Context, celebrity *models.Cele
 		&created.PhotoURL,
 		&created.Summary,
 		&created.LastUpdated,
+		&created.DeathDate,
 	)
 
 	if err != nil {
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type CeremonyRepository struct {
+	db DBTX
+}
+
+func NewCeremonyRepository(db DBTX) *CeremonyRepository {
+	return &CeremonyRepository{db: db}
+}
+
+// upcomingNominationsCTE lists upcoming nominations with a ceremony date,
+// from both the awards table and the Oscar race. Oscar race nominees are
+// only included when linked to a celebrity.
+const upcomingNominationsCTE = `
+	WITH nominations AS (
+		SELECT type, year, ceremony_date, NULL::text AS ceremony_name, false AS in_oscar_race,
+			celebrity_id, category, work
+		FROM awards
+		WHERE is_upcoming = true AND ceremony_date >= CURRENT_DATE
+		UNION
+		SELECT 'Oscar'::award_type, oc.year, oc.ceremony_date, oc.ceremony_name, true,
+			n.celebrity_id, cat.name, COALESCE(n.work_title, '')
+		FROM oscar_ceremonies oc
+		LEFT JOIN oscar_categories cat ON cat.ceremony_id = oc.id
+		LEFT JOIN oscar_nominees n ON n.category_id = cat.id
+		WHERE oc.is_complete IS NOT TRUE AND oc.ceremony_date >= CURRENT_DATE
+	)
+`
+
+// FindUpcoming returns ceremonies with a date from today on, in date order,
+// with every tracked celebrity nominated at each. A ceremony is identified
+// by its award type and date, so the Oscar race and Oscar nominations in
+// the awards table are merged, preferring the Oscar race's year and name.
+func (r *CeremonyRepository) FindUpcoming(ctx context.Context) ([]models.Ceremony, error) {
+	query := upcomingNominationsCTE + `
+		SELECT type, COALESCE(MAX(year) FILTER (WHERE in_oscar_race), MIN(year)), ceremony_date, COALESCE(MAX(ceremony_name), ''), BOOL_OR(in_oscar_race)
+		FROM nominations
+		GROUP BY type, ceremony_date
+		ORDER BY ceremony_date, type
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ceremonies []models.Ceremony
+	for rows.Next() {
+		var c models.Ceremony
+		if err := rows.Scan(&c.Type, &c.Year, &c.Date, &c.Name, &c.InOscarRace); err != nil {
+			return nil, err
+		}
+		c.Nominees = []models.CeremonyNominee{}
+		ceremonies = append(ceremonies, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadNominees(ctx, ceremonies); err != nil {
+		return nil, err
+	}
+
+	return ceremonies, nil
+}
+
+// loadNominees fills in the nominees of each ceremony with their EGOT
+// progress, ordered by category and name
+func (r *CeremonyRepository) loadNominees(ctx context.Context, ceremonies []models.Ceremony) error {
+	if len(ceremonies) == 0 {
+		return nil
+	}
+
+	type ceremonyKey struct {
+		awardType models.AwardType
+		date      int64
+	}
+	byKey := make(map[ceremonyKey]*models.Ceremony, len(ceremonies))
+	for i := range ceremonies {
+		key := ceremonyKey{ceremonies[i].Type, ceremonies[i].Date.Time.Unix()}
+		byKey[key] = &ceremonies[i]
+	}
+
+	query := upcomingNominationsCTE + `,
+		won AS (
+			SELECT celebrity_id, ARRAY_AGG(DISTINCT type::text ORDER BY type::text) AS won_awards
+			FROM awards
+			WHERE is_winner = true AND is_upcoming = false
+			GROUP BY celebrity_id
+		)
+		SELECT n.type, n.ceremony_date, n.category, n.work,
+			c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date,
+			COALESCE(CARDINALITY(won.won_awards), 0), COALESCE(won.won_awards, '{}')
+		FROM nominations n
+		INNER JOIN celebrities c ON c.id = n.celebrity_id
+		LEFT JOIN won ON won.celebrity_id = n.celebrity_id
+		ORDER BY n.ceremony_date, n.type, n.category, c.name
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var awardType models.AwardType
+		var date pgtype.Date
+		var nominee models.CeremonyNominee
+		c := &nominee.Celebrity
+		err := rows.Scan(
+			&awardType,
+			&date,
+			&nominee.Category,
+			&nominee.Work,
+			&c.ID,
+			&c.Name,
+			&c.Slug,
+			&c.PhotoURL,
+			&c.Summary,
+			&c.LastUpdated,
+			&c.DeathDate,
+			&c.EGOTWinCount,
+			&c.WonAwards,
+		)
+		if err != nil {
+			return err
+		}
+		if ceremony, ok := byKey[ceremonyKey{awardType, date.Time.Unix()}]; ok {
+			ceremony.Nominees = append(ceremony.Nominees, nominee)
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type EventRepository struct {
+	db DBTX
+}
+
+func NewEventRepository(db DBTX) *EventRepository {
+	return &EventRepository{db: db}
+}
+
+// CreateBatch records events in one round trip. Milestones a celebrity has
+// already reached are skipped, so refreshing their awards is idempotent.
+func (r *EventRepository) CreateBatch(ctx context.Context, events []models.EGOTEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO egot_events (
+			celebrity_id, event_type, egot_win_count,
+			award_id, award_type, award_year, award_category, award_work, initial_import
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT DO NOTHING
+	`
+
+	batch := &pgx.Batch{}
+	for _, e := range events {
+		batch.Queue(query,
+			e.Celebrity.ID,
+			e.Type,
+			e.EGOTWinCount,
+			e.Award.ID,
+			e.Award.Type,
+			e.Award.Year,
+			e.Award.Category,
+			e.Award.Work,
+			e.InitialImport,
+		)
+	}
+
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// Backfill records the milestones already reached by every celebrity's
+// awards that have no event yet, as initial imports, and returns how many
+// were recorded. It is run for awards written in bulk rather than through a
+// scrape, and is the one place milestones are ranked in SQL: migration 0012
+// holds a frozen copy from when events were introduced, which is never
+// updated.
+func (r *EventRepository) Backfill(ctx context.Context) (int64, error) {
+	query := `
+		WITH first_wins AS (
+			SELECT DISTINCT ON (celebrity_id, type)
+				celebrity_id, id, type, year, category, work, ceremony_date
+			FROM awards
+			WHERE is_winner = true AND is_upcoming = false
+			ORDER BY celebrity_id, type, (year = 0), year, ceremony_date NULLS LAST, id
+		),
+		ranked AS (
+			SELECT *, ROW_NUMBER() OVER (
+				PARTITION BY celebrity_id
+				ORDER BY (year = 0), year, ceremony_date NULLS LAST, type
+			) AS win_count
+			FROM first_wins
+		)
+		INSERT INTO egot_events (
+			celebrity_id, event_type, egot_win_count,
+			award_id, award_type, award_year, award_category, award_work, initial_import
+		)
+		SELECT r.celebrity_id, t.event_type, r.win_count,
+			r.id, r.type, r.year, r.category, r.work, true
+		FROM ranked r
+		CROSS JOIN LATERAL (
+			SELECT 'first_win' AS event_type
+			UNION ALL SELECT 'reached_three' WHERE r.win_count = 3
+			UNION ALL SELECT 'completed_egot' WHERE r.win_count = 4
+		) t
+		ON CONFLICT DO NOTHING
+	`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Find returns a page of events matching the filter, newest first, along
+// with the total number of matching events
+func (r *EventRepository) Find(ctx context.Context, filter models.EGOTEventFilter, page pagination.Params) ([]models.EGOTEvent, int64, error) {
+	cursorKey, cursorID := cursorArgs(page.Cursor)
+
+	types := make([]string, len(filter.Types))
+	for i, t := range filter.Types {
+		types[i] = string(t)
+	}
+
+	const matching = `
+		(CARDINALITY($1::text[]) = 0 OR event_type = ANY($1::text[]))
+		AND ($2::uuid IS NULL OR celebrity_id = $2::uuid)
+		AND NOT ($3::boolean AND initial_import)
+	`
+
+	query := `
+		SELECT e.id, e.event_type, e.egot_win_count,
+			e.award_id, e.award_type, e.award_year, e.award_category, e.award_work,
+			e.initial_import, e.created_at,
+			c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM egot_events e
+		INNER JOIN celebrities c ON c.id = e.celebrity_id
+		WHERE ` + matching + `
+		  AND ($5::text IS NULL
+		       OR e.created_at < $5::text::timestamptz
+		       OR (e.created_at = $5::text::timestamptz AND e.id > $6::text::uuid))
+		ORDER BY e.created_at DESC, e.id
+		LIMIT $4
+	`
+
+	return queryPage(ctx, r.db,
+		sqlQuery{query, []any{types, filter.CelebrityID, filter.ExcludeInitial, page.Limit + 1, cursorKey, cursorID}},
+		sqlQuery{"SELECT COUNT(*) FROM egot_events WHERE " + matching, []any{types, filter.CelebrityID, filter.ExcludeInitial}},
+		func(row pgx.CollectableRow) (models.EGOTEvent, error) {
+			var e models.EGOTEvent
+			err := row.Scan(
+				&e.ID,
+				&e.Type,
+				&e.EGOTWinCount,
+				&e.Award.ID,
+				&e.Award.Type,
+				&e.Award.Year,
+				&e.Award.Category,
+				&e.Award.Work,
+				&e.InitialImport,
+				&e.CreatedAt,
+				&e.Celebrity.ID,
+				&e.Celebrity.Name,
+				&e.Celebrity.Slug,
+				&e.Celebrity.PhotoURL,
+				&e.Celebrity.Summary,
+				&e.Celebrity.LastUpdated,
+				&e.Celebrity.DeathDate,
+			)
+			return e, err
+		})
+}
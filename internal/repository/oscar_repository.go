@@ -5,20 +5,23 @@ import (
 	"errors"
 
 	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var ErrCeremonyNotFound = errors.New("ceremony not found")
+var (
+	ErrCeremonyNotFound = errors.New("ceremony not found")
+	ErrNomineeNotFound  = errors.New("nominee not found")
+)
 
 type OscarRepository struct {
-	pool *pgxpool.Pool
+	db DBTX
 }
 
-func NewOscarRepository(pool *pgxpool.Pool) *OscarRepository {
-	return &OscarRepository{pool: pool}
+func NewOscarRepository(db DBTX) *OscarRepository {
+	return &OscarRepository{db: db}
 }
 
 // CreateCeremony creates a new Oscar ceremony
@@ -30,7 +33,7 @@ func (r *OscarRepository) CreateCeremony(ctx context.Context, ceremony *models.O
 	`
 
 	var created models.OscarCeremony
-	err := r.pool.QueryRow(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		ceremony.Year,
 		ceremony.CeremonyName,
 		ceremony.CeremonyDate,
@@ -60,7 +63,7 @@ func (r *OscarRepository) GetCeremonyByYear(ctx context.Context, year int) (*mod
 	`
 
 	var ceremony models.OscarCeremony
-	err := r.pool.QueryRow(ctx, query, year).Scan(
+	err := r.db.QueryRow(ctx, query, year).Scan(
 		&ceremony.ID,
 		&ceremony.Year,
 		&ceremony.CeremonyName,
@@ -79,26 +82,23 @@ func (r *OscarRepository) GetCeremonyByYear(ctx context.Context, year int) (*mod
 	return &ceremony, nil
 }
 
-// GetAllCeremonyYears returns all tracked Oscar years
-func (r *OscarRepository) GetAllCeremonyYears(ctx context.Context) ([]int, error) {
-	query := `SELECT year FROM oscar_ceremonies ORDER BY year DESC`
-
-	rows, err := r.pool.Query(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var years []int
-	for rows.Next() {
-		var year int
-		if err := rows.Scan(&year); err != nil {
-			return nil, err
-		}
-		years = append(years, year)
-	}
+// GetAllCeremonyYears returns a page of tracked Oscar years, most recent
+// first, along with the total number of tracked years
+func (r *OscarRepository) GetAllCeremonyYears(ctx context.Context, page pagination.Params) ([]int, int64, error) {
+	query := `
+		SELECT year
+		FROM oscar_ceremonies
+		WHERE $2::text IS NULL OR year < $2::text::int
+		ORDER BY year DESC
+		LIMIT $1
+	`
 
-	return years, rows.Err()
+	cursorKey, _ := cursorArgs(page.Cursor)
+	return queryPage(ctx, r.db,
+		sqlQuery{query, []any{page.Limit + 1, cursorKey}},
+		sqlQuery{"SELECT COUNT(*) FROM oscar_ceremonies", nil},
+		pgx.RowTo[int],
+	)
 }
 
 // CreateCategory creates a new Oscar category
@@ -110,7 +110,7 @@ func (r *OscarRepository) CreateCategory(ctx context.Context, category *models.O
 	`
 
 	var created models.OscarCategory
-	err := r.pool.QueryRow(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		category.CeremonyID,
 		category.Name,
 		category.DisplayOrder,
@@ -139,7 +139,36 @@ func (r *OscarRepository) GetCategoriesByCeremony(ctx context.Context, ceremonyI
 		ORDER BY display_order
 	`
 
-	rows, err := r.pool.Query(ctx, query, ceremonyID)
+	rows, err := r.db.Query(ctx, query, ceremonyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []models.OscarCategory
+	for rows.Next() {
+		var c models.OscarCategory
+		err := rows.Scan(&c.ID, &c.CeremonyID, &c.Name, &c.DisplayOrder, &c.WinnerAnnounced)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+
+	return categories, rows.Err()
+}
+
+// GetCategoriesByCeremonies fetches the categories of several ceremonies in
+// one query
+func (r *OscarRepository) GetCategoriesByCeremonies(ctx context.Context, ceremonyIDs []pgtype.UUID) ([]models.OscarCategory, error) {
+	query := `
+		SELECT id, ceremony_id, name, display_order, winner_announced
+		FROM oscar_categories
+		WHERE ceremony_id = ANY($1)
+		ORDER BY display_order
+	`
+
+	rows, err := r.db.Query(ctx, query, ceremonyIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +196,7 @@ func (r *OscarRepository) CreateNominee(ctx context.Context, nominee *models.Osc
 	`
 
 	var created models.OscarNominee
-	err := r.pool.QueryRow(ctx, query,
+	err := r.db.QueryRow(ctx, query,
 		nominee.CategoryID,
 		nominee.CelebrityID,
 		nominee.Name,
@@ -202,7 +231,36 @@ func (r *OscarRepository) GetNomineesByCategory(ctx context.Context, categoryID
 		ORDER BY display_order
 	`
 
-	rows, err := r.pool.Query(ctx, query, categoryID)
+	rows, err := r.db.Query(ctx, query, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var nominees []models.OscarNominee
+	for rows.Next() {
+		var n models.OscarNominee
+		err := rows.Scan(&n.ID, &n.CategoryID, &n.CelebrityID, &n.Name, &n.PhotoURL, &n.WorkTitle, &n.IsWinner, &n.DisplayOrder)
+		if err != nil {
+			return nil, err
+		}
+		nominees = append(nominees, n)
+	}
+
+	return nominees, rows.Err()
+}
+
+// GetNomineesByCategories fetches the nominees of several categories in one
+// query
+func (r *OscarRepository) GetNomineesByCategories(ctx context.Context, categoryIDs []pgtype.UUID) ([]models.OscarNominee, error) {
+	query := `
+		SELECT id, category_id, celebrity_id, name, photo_url, work_title, is_winner, display_order
+		FROM oscar_nominees
+		WHERE category_id = ANY($1)
+		ORDER BY display_order
+	`
+
+	rows, err := r.db.Query(ctx, query, categoryIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -261,30 +319,33 @@ func (r *OscarRepository) GetFullCeremony(ctx context.Context, year int) (*model
 func (r *OscarRepository) SetNomineeAsWinner(ctx context.Context, nomineeID pgtype.UUID) error {
 	// First, get the category ID for this nominee
 	var categoryID pgtype.UUID
-	err := r.pool.QueryRow(ctx, "SELECT category_id FROM oscar_nominees WHERE id = $1", nomineeID).Scan(&categoryID)
+	err := r.db.QueryRow(ctx, "SELECT category_id FROM oscar_nominees WHERE id = $1", nomineeID).Scan(&categoryID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNomineeNotFound
+	}
 	if err != nil {
 		return err
 	}
 
 	// Reset all winners in this category
-	_, err = r.pool.Exec(ctx, "UPDATE oscar_nominees SET is_winner = false WHERE category_id = $1", categoryID)
+	_, err = r.db.Exec(ctx, "UPDATE oscar_nominees SET is_winner = false WHERE category_id = $1", categoryID)
 	if err != nil {
 		return err
 	}
 
 	// Set this nominee as winner
-	_, err = r.pool.Exec(ctx, "UPDATE oscar_nominees SET is_winner = true WHERE id = $1", nomineeID)
+	_, err = r.db.Exec(ctx, "UPDATE oscar_nominees SET is_winner = true WHERE id = $1", nomineeID)
 	if err != nil {
 		return err
 	}
 
 	// Mark category as winner announced
-	_, err = r.pool.Exec(ctx, "UPDATE oscar_categories SET winner_announced = true WHERE id = $1", categoryID)
+	_, err = r.db.Exec(ctx, "UPDATE oscar_categories SET winner_announced = true WHERE id = $1", categoryID)
 	return err
 }
 
 // DeleteCeremony removes a ceremony and all related data
 func (r *OscarRepository) DeleteCeremony(ctx context.Context, year int) error {
-	_, err := r.pool.Exec(ctx, "DELETE FROM oscar_ceremonies WHERE year = $1", year)
+	_, err := r.db.Exec(ctx, "DELETE FROM oscar_ceremonies WHERE year = $1", year)
 	return err
 }
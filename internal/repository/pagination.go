@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"egot-tracker/internal/pagination"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// cursorArgs returns the query arguments for a keyset cursor, or two NULLs
+// when fetching the first page. Cursors are validated against their scope
+// when parsed, so the casts applied to them in queries cannot fail.
+func cursorArgs(cursor *pagination.Cursor) (any, any) {
+	if cursor == nil {
+		return nil, nil
+	}
+	return cursor.Key, cursor.ID
+}
+
+// sqlQuery is a query with its arguments
+type sqlQuery struct {
+	sql  string
+	args []any
+}
+
+// queryPage runs a query for a page of rows and a query counting all of
+// the matching rows in one round trip. The total is counted on its own, so
+// a page past the end still reports it.
+func queryPage[T any](ctx context.Context, db DBTX, page, count sqlQuery, scan pgx.RowToFunc[T]) ([]T, int64, error) {
+	batch := &pgx.Batch{}
+	batch.Queue(count.sql, count.args...)
+	batch.Queue(page.sql, page.args...)
+
+	results := db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	var total int64
+	if err := results.QueryRow().Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := results.Query()
+	if err != nil {
+		return nil, 0, err
+	}
+	items, err := pgx.CollectRows(rows, scan)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return items, total, nil
+}
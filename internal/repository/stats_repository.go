@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type StatsRepository struct {
+	db DBTX
+}
+
+func NewStatsRepository(db DBTX) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+// GetStats computes aggregate counts over celebrities and their past awards.
+// The four aggregate queries are sent in a single round trip.
+func (r *StatsRepository) GetStats(ctx context.Context) (*models.Stats, error) {
+	batch := &pgx.Batch{}
+
+	batch.Queue(`
+		SELECT
+			t.type,
+			COUNT(DISTINCT a.celebrity_id) FILTER (WHERE a.is_winner),
+			COUNT(a.id) FILTER (WHERE a.is_winner),
+			COUNT(a.id)
+		FROM UNNEST(enum_range(NULL::award_type)) AS t(type)
+		LEFT JOIN awards a ON a.type = t.type AND a.is_upcoming = false
+		GROUP BY t.type
+		ORDER BY t.type
+	`)
+
+	batch.Queue(`
+		SELECT
+			(year / 10) * 10 AS decade,
+			COUNT(*) FILTER (WHERE is_winner AND type = 'Emmy'),
+			COUNT(*) FILTER (WHERE is_winner AND type = 'Grammy'),
+			COUNT(*) FILTER (WHERE is_winner AND type = 'Oscar'),
+			COUNT(*) FILTER (WHERE is_winner AND type = 'Tony'),
+			COUNT(*)
+		FROM awards
+		WHERE is_upcoming = false AND year > 0
+		GROUP BY decade
+		ORDER BY decade
+	`)
+
+	batch.Queue(`
+		WITH ` + celebrityStatsCTE + `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE egot_win_count = 0),
+			COUNT(*) FILTER (WHERE egot_win_count = 1),
+			COUNT(*) FILTER (WHERE egot_win_count = 2),
+			COUNT(*) FILTER (WHERE egot_win_count = 3),
+			COUNT(*) FILTER (WHERE egot_win_count = 4)
+		FROM stats
+	`)
+
+	batch.Queue(`
+		SELECT
+			type,
+			award_sub_body(type, category) AS sub_body,
+			COUNT(*) FILTER (WHERE is_winner),
+			COUNT(*)
+		FROM awards
+		WHERE is_upcoming = false
+		GROUP BY type, sub_body
+		ORDER BY type, sub_body
+	`)
+
+	results := r.db.SendBatch(ctx, batch)
+	defer results.Close()
+
+	stats := &models.Stats{
+		ByAwardType: []models.AwardTypeStats{},
+		ByDecade:    []models.DecadeStats{},
+		ByProgress:  make(map[int]int64, 5),
+		BySubBody:   []models.SubBodyStats{},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	rows, err := results.Query()
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var s models.AwardTypeStats
+		if err := rows.Scan(&s.Type, &s.Winners, &s.Wins, &s.Nominations); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		stats.ByAwardType = append(stats.ByAwardType, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = results.Query()
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var s models.DecadeStats
+		var wins [4]int64
+		if err := rows.Scan(&s.Decade, &wins[0], &wins[1], &wins[2], &wins[3], &s.Nominations); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		s.WinsByType = make(map[models.AwardType]int64, len(wins))
+		for i, awardType := range models.AllAwardTypes {
+			s.WinsByType[awardType] = wins[i]
+			s.Wins += wins[i]
+		}
+		stats.ByDecade = append(stats.ByDecade, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var progress [5]int64
+	err = results.QueryRow().Scan(
+		&stats.Celebrities,
+		&progress[0], &progress[1], &progress[2], &progress[3], &progress[4],
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i, count := range progress {
+		stats.ByProgress[i] = count
+	}
+
+	rows, err = results.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s models.SubBodyStats
+		if err := rows.Scan(&s.Type, &s.SubBody, &s.Wins, &s.Nominations); err != nil {
+			return nil, err
+		}
+		stats.BySubBody = append(stats.BySubBody, s)
+	}
+
+	return stats, rows.Err()
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBTX is the subset of pgx shared by *pgxpool.Pool and pgx.Tx, so the same
+// repository code can run standalone or inside a transaction
+type DBTX interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// Repositories bundles repositories bound to a single transaction
+type Repositories struct {
+	Celebrities *CelebrityRepository
+	Awards      *AwardRepository
+	Aliases     *AliasRepository
+	Oscars      *OscarRepository
+	Works       *WorkRepository
+	Watchlists  *WatchlistRepository
+	Webhooks    *WebhookRepository
+	Events      *EventRepository
+}
+
+// NewRepositories returns repositories sharing db, typically a transaction
+func NewRepositories(db DBTX) *Repositories {
+	return &Repositories{
+		Celebrities: NewCelebrityRepository(db),
+		Awards:      NewAwardRepository(db),
+		Aliases:     NewAliasRepository(db),
+		Oscars:      NewOscarRepository(db),
+		Works:       NewWorkRepository(db),
+		Watchlists:  NewWatchlistRepository(db),
+		Webhooks:    NewWebhookRepository(db),
+		Events:      NewEventRepository(db),
+	}
+}
+
+// UnitOfWork runs several repository operations atomically
+type UnitOfWork struct {
+	pool *pgxpool.Pool
+}
+
+func NewUnitOfWork(pool *pgxpool.Pool) *UnitOfWork {
+	return &UnitOfWork{pool: pool}
+}
+
+// WithTryLock calls fn while holding the session advisory lock lockID, if no
+// other session holds it. It reports whether fn was called.
+func (u *UnitOfWork) WithTryLock(ctx context.Context, lockID int64, fn func() error) (bool, error) {
+	conn, err := u.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", lockID).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked {
+		return false, nil
+	}
+	defer conn.Exec(context.Background(), "SELECT pg_advisory_unlock($1)", lockID)
+
+	return true, fn()
+}
+
+// WithinTx calls fn with repositories sharing one transaction. The transaction
+// is committed if fn returns nil and rolled back otherwise.
+func (u *UnitOfWork) WithinTx(ctx context.Context, fn func(repos *Repositories) error) error {
+	return pgx.BeginFunc(ctx, u.pool, func(tx pgx.Tx) error {
+		return fn(NewRepositories(tx))
+	})
+}
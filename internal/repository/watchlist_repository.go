@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrWatchlistNotFound = errors.New("watchlist not found")
+
+type WatchlistRepository struct {
+	db DBTX
+}
+
+func NewWatchlistRepository(db DBTX) *WatchlistRepository {
+	return &WatchlistRepository{db: db}
+}
+
+// Create stores a new watchlist
+func (r *WatchlistRepository) Create(ctx context.Context, watchlist *models.Watchlist) (*models.Watchlist, error) {
+	query := `
+		INSERT INTO watchlists (api_key_id, name, webhook_url, webhook_secret)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, api_key_id, name, webhook_url, webhook_secret, created_at
+	`
+
+	var created models.Watchlist
+	err := r.db.QueryRow(ctx, query,
+		watchlist.APIKeyID,
+		watchlist.Name,
+		watchlist.WebhookURL,
+		watchlist.WebhookSecret,
+	).Scan(
+		&created.ID,
+		&created.APIKeyID,
+		&created.Name,
+		&created.WebhookURL,
+		&created.WebhookSecret,
+		&created.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// FindByAPIKey returns the watchlists owned by an API key, oldest first
+func (r *WatchlistRepository) FindByAPIKey(ctx context.Context, apiKeyID pgtype.UUID) ([]models.Watchlist, error) {
+	query := `
+		SELECT id, api_key_id, name, webhook_url, webhook_secret, created_at
+		FROM watchlists
+		WHERE api_key_id = $1
+		ORDER BY created_at, id
+	`
+
+	return r.query(ctx, query, apiKeyID)
+}
+
+// FindWatching returns the watchlists with a webhook that include a celebrity
+func (r *WatchlistRepository) FindWatching(ctx context.Context, celebrityID pgtype.UUID) ([]models.Watchlist, error) {
+	query := `
+		SELECT w.id, w.api_key_id, w.name, w.webhook_url, w.webhook_secret, w.created_at
+		FROM watchlists w
+		INNER JOIN watchlist_celebrities wc ON wc.watchlist_id = w.id
+		WHERE wc.celebrity_id = $1 AND w.webhook_url IS NOT NULL
+		ORDER BY w.created_at, w.id
+	`
+
+	return r.query(ctx, query, celebrityID)
+}
+
+func (r *WatchlistRepository) query(ctx context.Context, query string, args ...any) ([]models.Watchlist, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watchlists []models.Watchlist
+	for rows.Next() {
+		var w models.Watchlist
+		err := rows.Scan(&w.ID, &w.APIKeyID, &w.Name, &w.WebhookURL, &w.WebhookSecret, &w.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		watchlists = append(watchlists, w)
+	}
+
+	return watchlists, rows.Err()
+}
+
+// FindByID returns a watchlist owned by an API key
+func (r *WatchlistRepository) FindByID(ctx context.Context, apiKeyID, id pgtype.UUID) (*models.Watchlist, error) {
+	query := `
+		SELECT id, api_key_id, name, webhook_url, webhook_secret, created_at
+		FROM watchlists
+		WHERE id = $1 AND api_key_id = $2
+	`
+
+	var w models.Watchlist
+	err := r.db.QueryRow(ctx, query, id, apiKeyID).Scan(
+		&w.ID,
+		&w.APIKeyID,
+		&w.Name,
+		&w.WebhookURL,
+		&w.WebhookSecret,
+		&w.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWatchlistNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// FindByCalendarTokenHash returns the watchlist whose calendar token has the
+// given hash
+func (r *WatchlistRepository) FindByCalendarTokenHash(ctx context.Context, hash string) (*models.Watchlist, error) {
+	query := `
+		SELECT id, api_key_id, name, webhook_url, webhook_secret, created_at
+		FROM watchlists
+		WHERE calendar_token_hash = $1
+	`
+
+	var w models.Watchlist
+	err := r.db.QueryRow(ctx, query, hash).Scan(
+		&w.ID,
+		&w.APIKeyID,
+		&w.Name,
+		&w.WebhookURL,
+		&w.WebhookSecret,
+		&w.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWatchlistNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &w, nil
+}
+
+// SetCalendarTokenHash replaces the calendar token of a watchlist owned by an
+// API key
+func (r *WatchlistRepository) SetCalendarTokenHash(ctx context.Context, apiKeyID, id pgtype.UUID, hash string) error {
+	tag, err := r.db.Exec(ctx,
+		"UPDATE watchlists SET calendar_token_hash = $3 WHERE id = $1 AND api_key_id = $2",
+		id, apiKeyID, hash,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWatchlistNotFound
+	}
+	return nil
+}
+
+// Delete removes a watchlist owned by an API key
+func (r *WatchlistRepository) Delete(ctx context.Context, apiKeyID, id pgtype.UUID) error {
+	tag, err := r.db.Exec(ctx, "DELETE FROM watchlists WHERE id = $1 AND api_key_id = $2", id, apiKeyID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWatchlistNotFound
+	}
+	return nil
+}
+
+// AddCelebrity puts a celebrity on a watchlist. Adding one already on it is a no-op.
+func (r *WatchlistRepository) AddCelebrity(ctx context.Context, watchlistID, celebrityID pgtype.UUID) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO watchlist_celebrities (watchlist_id, celebrity_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, watchlistID, celebrityID)
+	return err
+}
+
+// RemoveCelebrity takes a celebrity off a watchlist
+func (r *WatchlistRepository) RemoveCelebrity(ctx context.Context, watchlistID, celebrityID pgtype.UUID) error {
+	_, err := r.db.Exec(ctx,
+		"DELETE FROM watchlist_celebrities WHERE watchlist_id = $1 AND celebrity_id = $2",
+		watchlistID, celebrityID,
+	)
+	return err
+}
+
+// FindCelebrities returns the celebrities on a watchlist by name
+func (r *WatchlistRepository) FindCelebrities(ctx context.Context, watchlistID pgtype.UUID) ([]models.Celebrity, error) {
+	query := `
+		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date
+		FROM watchlist_celebrities wc
+		INNER JOIN celebrities c ON c.id = wc.celebrity_id
+		WHERE wc.watchlist_id = $1
+		ORDER BY c.name
+	`
+
+	rows, err := r.db.Query(ctx, query, watchlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var celebrities []models.Celebrity
+	for rows.Next() {
+		var c models.Celebrity
+		err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.PhotoURL, &c.Summary, &c.LastUpdated, &c.DeathDate)
+		if err != nil {
+			return nil, err
+		}
+		celebrities = append(celebrities, c)
+	}
+
+	return celebrities, rows.Err()
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type WebhookRepository struct {
+	db DBTX
+}
+
+func NewWebhookRepository(db DBTX) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Enqueue adds deliveries to the outbox in one round trip. They become due
+// immediately. A delivery whose dedupe key was already queued for its
+// watchlist is skipped.
+func (r *WebhookRepository) Enqueue(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (watchlist_id, event_type, payload, dedupe_key)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (watchlist_id, dedupe_key) DO NOTHING
+	`
+
+	batch := &pgx.Batch{}
+	for _, d := range deliveries {
+		batch.Queue(query, d.WatchlistID, d.EventType, d.Payload, d.DedupeKey)
+	}
+
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// ClaimDue returns up to limit pending deliveries that are due, with their
+// webhook URL and secret. Claimed deliveries are pushed back by lease, so
+// another dispatcher will not send them again unless this one fails to
+// record an attempt in time.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2)
+		FROM watchlists w
+		WHERE w.id = d.watchlist_id
+		  AND d.id IN (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING d.id, d.watchlist_id, d.event_type, d.payload, d.status, d.attempts,
+			d.next_attempt_at, d.created_at, d.delivered_at,
+			COALESCE(w.webhook_url, ''), w.webhook_secret
+	`
+
+	rows, err := r.db.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		var d models.WebhookDelivery
+		err := rows.Scan(
+			&d.ID,
+			&d.WatchlistID,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&d.NextAttemptAt,
+			&d.CreatedAt,
+			&d.DeliveredAt,
+			&d.WebhookURL,
+			&d.WebhookSecret,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// RecordAttempt logs an attempt to send a delivery and moves the delivery to
+// status. Pending deliveries are retried at nextAttemptAt.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, attempt models.WebhookDeliveryAttempt, status models.WebhookDeliveryStatus, nextAttemptAt time.Time) error {
+	query := `
+		WITH attempt AS (
+			INSERT INTO webhook_delivery_attempts (delivery_id, status_code, error, duration_ms)
+			VALUES ($1, $2, $3, $4)
+		)
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+			status = $5,
+			next_attempt_at = $6,
+			delivered_at = CASE WHEN $5 = 'delivered' THEN NOW() ELSE delivered_at END
+		WHERE id = $1
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		attempt.DeliveryID,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.DurationMs,
+		status,
+		nextAttemptAt,
+	)
+	return err
+}
+
+// FindByWatchlist returns a page of a watchlist's deliveries, newest first,
+// each with its attempt log, along with the total number of deliveries
+func (r *WebhookRepository) FindByWatchlist(ctx context.Context, watchlistID pgtype.UUID, page pagination.Params) ([]models.WebhookDeliveryWithAttempts, int64, error) {
+	cursorKey, cursorID := cursorArgs(page.Cursor)
+
+	query := `
+		SELECT id, watchlist_id, event_type, payload, status, attempts,
+			next_attempt_at, created_at, delivered_at
+		FROM webhook_deliveries
+		WHERE watchlist_id = $1
+		  AND ($3::text IS NULL
+		       OR created_at < $3::text::timestamptz
+		       OR (created_at = $3::text::timestamptz AND id > $4::text::uuid))
+		ORDER BY created_at DESC, id
+		LIMIT $2
+	`
+
+	deliveries, total, err := queryPage(ctx, r.db,
+		sqlQuery{query, []any{watchlistID, page.Limit + 1, cursorKey, cursorID}},
+		sqlQuery{"SELECT COUNT(*) FROM webhook_deliveries WHERE watchlist_id = $1", []any{watchlistID}},
+		func(row pgx.CollectableRow) (models.WebhookDeliveryWithAttempts, error) {
+			var d models.WebhookDeliveryWithAttempts
+			err := row.Scan(
+				&d.ID,
+				&d.WatchlistID,
+				&d.EventType,
+				&d.Payload,
+				&d.Status,
+				&d.Attempts,
+				&d.NextAttemptAt,
+				&d.CreatedAt,
+				&d.DeliveredAt,
+			)
+			d.AttemptLog = []models.WebhookDeliveryAttempt{}
+			return d, err
+		})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err := r.loadAttempts(ctx, deliveries); err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+// loadAttempts fills in the attempt log of each delivery, oldest attempt first
+func (r *WebhookRepository) loadAttempts(ctx context.Context, deliveries []models.WebhookDeliveryWithAttempts) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ids := make([]pgtype.UUID, len(deliveries))
+	byID := make(map[[16]byte]*models.WebhookDeliveryWithAttempts, len(deliveries))
+	for i := range deliveries {
+		ids[i] = deliveries[i].ID
+		byID[deliveries[i].ID.Bytes] = &deliveries[i]
+	}
+
+	query := `
+		SELECT id, delivery_id, attempted_at, status_code, error, duration_ms
+		FROM webhook_delivery_attempts
+		WHERE delivery_id = ANY($1)
+		ORDER BY attempted_at, id
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.WebhookDeliveryAttempt
+		err := rows.Scan(&a.ID, &a.DeliveryID, &a.AttemptedAt, &a.StatusCode, &a.Error, &a.DurationMs)
+		if err != nil {
+			return err
+		}
+		if d, ok := byID[a.DeliveryID.Bytes]; ok {
+			d.AttemptLog = append(d.AttemptLog, a)
+		}
+	}
+
+	return rows.Err()
+}
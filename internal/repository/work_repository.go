@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrWorkNotFound = errors.New("work not found")
+
+type WorkRepository struct {
+	db DBTX
+}
+
+func NewWorkRepository(db DBTX) *WorkRepository {
+	return &WorkRepository{db: db}
+}
+
+// FindByID returns the work with the given ID
+func (r *WorkRepository) FindByID(ctx context.Context, id pgtype.UUID) (*models.Work, error) {
+	return r.findOne(ctx, "id = $1", id)
+}
+
+// FindByWikidataID returns the work for a Wikidata QID, e.g. "Q1140578"
+func (r *WorkRepository) FindByWikidataID(ctx context.Context, wikidataID string) (*models.Work, error) {
+	return r.findOne(ctx, "wikidata_id = $1", wikidataID)
+}
+
+func (r *WorkRepository) findOne(ctx context.Context, where string, arg any) (*models.Work, error) {
+	query := `
+		SELECT id, wikidata_id, title, type, created_at
+		FROM works
+		WHERE ` + where
+
+	var work models.Work
+	err := r.db.QueryRow(ctx, query, arg).Scan(
+		&work.ID,
+		&work.WikidataID,
+		&work.Title,
+		&work.Type,
+		&work.CreatedAt,
+	)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWorkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &work, nil
+}
+
+// FindByIDs returns the works with the given IDs in one query
+func (r *WorkRepository) FindByIDs(ctx context.Context, ids []pgtype.UUID) ([]models.Work, error) {
+	query := `
+		SELECT id, wikidata_id, title, type, created_at
+		FROM works
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var works []models.Work
+	for rows.Next() {
+		var w models.Work
+		if err := rows.Scan(&w.ID, &w.WikidataID, &w.Title, &w.Type, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		works = append(works, w)
+	}
+
+	return works, rows.Err()
+}
+
+// UpsertBatch saves works on their Wikidata ID in one round trip, refreshing
+// the title of existing works. A known type is never replaced by "other".
+func (r *WorkRepository) UpsertBatch(ctx context.Context, works []models.Work) error {
+	if len(works) == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO works (wikidata_id, title, type)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (wikidata_id) DO UPDATE SET
+			title = EXCLUDED.title,
+			type = CASE WHEN EXCLUDED.type = 'other' THEN works.type ELSE EXCLUDED.type END
+	`
+
+	batch := &pgx.Batch{}
+	seen := make(map[string]bool, len(works))
+	for _, work := range works {
+		if work.WikidataID == "" || seen[work.WikidataID] {
+			continue
+		}
+		seen[work.WikidataID] = true
+
+		workType := work.Type
+		if workType == "" {
+			workType = models.WorkTypeOther
+		}
+		batch.Queue(query, work.WikidataID, work.Title, workType)
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return r.db.SendBatch(ctx, batch).Close()
+}
+
+// LinkAwards points every award at the work matching its Wikidata work ID
+// and returns the number of awards updated
+func (r *WorkRepository) LinkAwards(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE awards a
+		SET work_id = w.id
+		FROM works w
+		WHERE a.wikidata_work_id = w.wikidata_id
+		  AND a.work_id IS DISTINCT FROM w.id
+	`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// LinkAwardsByTitle points unlinked awards without a Wikidata work ID, such
+// as seed and manual rows, at the work with the same title, ignoring case
+// and accents. Titles shared by several works are ambiguous and left
+// unlinked. It returns the number of awards updated.
+func (r *WorkRepository) LinkAwardsByTitle(ctx context.Context) (int64, error) {
+	query := `
+		WITH unique_titles AS (
+			SELECT immutable_unaccent(LOWER(title)) AS title, MIN(id::text)::uuid AS id, MIN(wikidata_id) AS wikidata_id
+			FROM works
+			GROUP BY immutable_unaccent(LOWER(title))
+			HAVING COUNT(*) = 1
+		)
+		UPDATE awards a
+		SET work_id = t.id, wikidata_work_id = t.wikidata_id
+		FROM unique_titles t
+		WHERE a.work_id IS NULL
+		  AND a.wikidata_work_id IS NULL
+		  AND a.work <> ''
+		  AND immutable_unaccent(LOWER(a.work)) = t.title
+	`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// FindCredits returns every tracked celebrity's awards for a work, grouped
+// by celebrity in name order
+func (r *WorkRepository) FindCredits(ctx context.Context, workID pgtype.UUID) ([]models.WorkCredit, error) {
+	query := `
+		SELECT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date,
+			a.id, a.celebrity_id, a.type, a.year, a.work, a.category, a.is_winner,
+			a.ceremony_date, a.is_upcoming, a.work_id
+		FROM awards a
+		INNER JOIN celebrities c ON c.id = a.celebrity_id
+		WHERE a.work_id = $1
+		ORDER BY c.name, c.id, a.year, a.type
+	`
+
+	rows, err := r.db.Query(ctx, query, workID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var credits []models.WorkCredit
+	for rows.Next() {
+		var c models.Celebrity
+		var a models.Award
+		err := rows.Scan(
+			&c.ID,
+			&c.Name,
+			&c.Slug,
+			&c.PhotoURL,
+			&c.Summary,
+			&c.LastUpdated,
+			&c.DeathDate,
+			&a.ID,
+			&a.CelebrityID,
+			&a.Type,
+			&a.Year,
+			&a.Work,
+			&a.Category,
+			&a.IsWinner,
+			&a.CeremonyDate,
+			&a.IsUpcoming,
+			&a.WorkID,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		// Rows are ordered by celebrity, so a new celebrity starts a new credit
+		if n := len(credits); n == 0 || credits[n-1].Celebrity.ID != c.ID {
+			credits = append(credits, models.WorkCredit{Celebrity: c})
+		}
+		last := &credits[len(credits)-1]
+		last.Awards = append(last.Awards, a)
+	}
+
+	return credits, rows.Err()
+}
+
+// FindAllWins returns every pairing of a celebrity with a work they won a
+// past award for
+func (r *WorkRepository) FindAllWins(ctx context.Context) ([]models.WorkWin, error) {
+	query := `
+		SELECT DISTINCT c.id, c.name, c.slug, c.photo_url, c.summary, c.last_updated, c.death_date,
+			w.id, w.wikidata_id, w.title, w.type, w.created_at
+		FROM awards a
+		INNER JOIN celebrities c ON c.id = a.celebrity_id
+		INNER JOIN works w ON w.id = a.work_id
+		WHERE a.is_winner = true AND a.is_upcoming = false
+	`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var wins []models.WorkWin
+	for rows.Next() {
+		var win models.WorkWin
+		err := rows.Scan(
+			&win.Celebrity.ID,
+			&win.Celebrity.Name,
+			&win.Celebrity.Slug,
+			&win.Celebrity.PhotoURL,
+			&win.Celebrity.Summary,
+			&win.Celebrity.LastUpdated,
+			&win.Celebrity.DeathDate,
+			&win.Work.ID,
+			&win.Work.WikidataID,
+			&win.Work.Title,
+			&win.Work.Type,
+			&win.Work.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		wins = append(wins, win)
+	}
+
+	return wins, rows.Err()
+}
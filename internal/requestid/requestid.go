@@ -0,0 +1,58 @@
+// Package requestid gives every request an ID, so an error response can be
+// matched to what the server was doing when it failed.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"egot-tracker/pkg/response"
+)
+
+type contextKey struct{}
+
+// maxLength bounds IDs accepted from clients
+const maxLength = 128
+
+// Middleware uses the request's X-Request-ID if it has a usable one and
+// generates an ID otherwise. The ID is echoed in the response and stored in
+// the request context.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(response.RequestIDHeader)
+		if !valid(id) {
+			id = generate()
+		}
+		w.Header().Set(response.RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey{}, id)))
+	})
+}
+
+// FromContext returns the ID of the request being served, or "" outside a
+// request
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// valid accepts short IDs of printable ASCII, so client-supplied IDs are
+// safe to echo in headers and logs
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func generate() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
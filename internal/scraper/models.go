@@ -1,5 +1,11 @@
 package scraper
 
+import (
+	"time"
+
+	"egot-tracker/internal/models"
+)
+
 // WikidataSearchResult represents a search result from Wikidata API
 type WikidataSearchResult struct {
 	ID          string `json:"id"`
@@ -14,12 +20,15 @@ type WikidataSearchResponse struct {
 
 // WikidataAward represents an award parsed from SPARQL results
 type WikidataAward struct {
-	AwardID   string
-	AwardName string
-	Year      int
-	Work      string
-	Category  string
-	IsWinner  bool
+	StatementID string // e.g. Q42$F078E5B3-..., identifies the exact P166 statement
+	AwardID     string // QID of the award item
+	AwardName   string
+	Year        int
+	Work        string
+	WorkID      string          // QID of the work item, if the statement names one
+	WorkType    models.WorkType // empty if there is no work item
+	Category    string
+	IsWinner    bool
 }
 
 // WikidataPersonInfo contains basic person information from Wikidata
@@ -28,6 +37,7 @@ type WikidataPersonInfo struct {
 	Name       string
 	PhotoURL   string
 	Summary    string
+	DeathDate  time.Time // zero if living or unknown
 }
 
 // SPARQLResponse represents the response from Wikidata SPARQL endpoint
@@ -40,11 +50,15 @@ type SPARQLResults struct {
 }
 
 type SPARQLBinding struct {
+	Statement   SPARQLValue `json:"statement"`
 	Award       SPARQLValue `json:"award"`
 	AwardLabel  SPARQLValue `json:"awardLabel"`
 	Year        SPARQLValue `json:"year"`
-	Work        SPARQLValue `json:"workLabel"`
+	Work        SPARQLValue `json:"work"`
+	WorkLabel   SPARQLValue `json:"workLabel"`
+	WorkClass   SPARQLValue `json:"workClass"`
 	Image       SPARQLValue `json:"image"`
+	DeathDate   SPARQLValue `json:"deathDate"`
 	PersonLabel SPARQLValue `json:"personLabel"`
 }
 
@@ -0,0 +1,62 @@
+package scraper
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/metrics"
+)
+
+var (
+	upstreamDuration = metrics.NewHistogram(
+		"egot_scraper_request_duration_seconds",
+		"Time taken by upstream calls made while scraping, by upstream host.",
+		[]float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		"upstream",
+	)
+	upstreamErrors = metrics.NewCounter(
+		"egot_scraper_errors_total",
+		"Upstream calls that failed or returned an error status, by upstream host.",
+		"upstream",
+	)
+)
+
+// instrumentedTransport logs and measures every upstream call, logging with
+// the logger of the request that caused it, so a slow search can be traced
+// to its Wikidata queries
+type instrumentedTransport struct {
+	base http.RoundTripper
+}
+
+func newTransport() http.RoundTripper {
+	return &instrumentedTransport{base: http.DefaultTransport}
+}
+
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	upstream := req.URL.Host
+	logger := logging.FromContext(req.Context()).With(
+		"upstream", upstream,
+		"upstream_method", req.Method,
+		"upstream_path", req.URL.Path,
+	)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start)
+	upstreamDuration.Observe(elapsed.Seconds(), upstream)
+	if err != nil {
+		upstreamErrors.Inc(upstream)
+		logger.Warn("upstream request failed", "duration_ms", elapsed.Milliseconds(), "error", err)
+		return nil, err
+	}
+
+	level := slog.LevelInfo
+	if resp.StatusCode >= http.StatusBadRequest {
+		upstreamErrors.Inc(upstream)
+		level = slog.LevelWarn
+	}
+	logger.Log(req.Context(), level, "upstream request", "status", resp.StatusCode, "duration_ms", elapsed.Milliseconds())
+	return resp, nil
+}
@@ -3,6 +3,7 @@ package scraper
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -11,6 +12,8 @@ import (
 	"time"
 
 	"egot-tracker/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // Wikidata IDs for EGOT award types
@@ -21,6 +24,20 @@ const (
 	TonyAwardID   = "Q191874"
 )
 
+// ScraperVersion is recorded on every award row this scraper produces.
+// Bump it whenever the query or parsing logic changes.
+const ScraperVersion = "1.2"
+
+// Prefixes of the entity and statement IRIs returned by the SPARQL endpoint
+const (
+	wikidataEntityPrefix    = "http://www.wikidata.org/entity/"
+	wikidataStatementPrefix = "http://www.wikidata.org/entity/statement/"
+)
+
+// ErrNotFound means Wikidata or Wikipedia has no entry for what was looked
+// up. Other errors are failures to reach them.
+var ErrNotFound = errors.New("not found")
+
 // WikidataScraper fetches celebrity award data from Wikidata
 type WikidataScraper struct {
 	httpClient *http.Client
@@ -30,7 +47,8 @@ type WikidataScraper struct {
 func NewWikidataScraper() *WikidataScraper {
 	return &WikidataScraper{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: newTransport(),
 		},
 	}
 }
@@ -71,7 +89,7 @@ func (w *WikidataScraper) SearchPerson(ctx context.Context, name string) (*Wikid
 	}
 
 	if len(searchResp.Search) == 0 {
-		return nil, fmt.Errorf("no results found for: %s", name)
+		return nil, fmt.Errorf("no results found for %s: %w", name, ErrNotFound)
 	}
 
 	// Try to find a result with EGOT awards (disambiguation)
@@ -151,7 +169,7 @@ ASK {
 func (w *WikidataScraper) GetPersonWithAwards(ctx context.Context, wikidataID string) (*WikidataPersonInfo, []WikidataAward, error) {
 	// SPARQL query to get person info, photo, and ALL awards (filter in code)
 	query := fmt.Sprintf(`
-SELECT DISTINCT ?personLabel ?image ?award ?awardLabel ?year ?workLabel WHERE {
+SELECT DISTINCT ?statement ?personLabel ?image ?deathDate ?award ?awardLabel ?year ?work ?workLabel ?workClass WHERE {
   wd:%s p:P166 ?statement .
   ?statement ps:P166 ?award .
 
@@ -161,15 +179,19 @@ SELECT DISTINCT ?personLabel ?image ?award ?awardLabel ?year ?workLabel WHERE {
   # Try multiple properties for the work (P1686=for work, P1411=nominated for, P972=catalog)
   OPTIONAL {
     ?statement pq:P1686|pq:P1411|pq:P972 ?work .
+    OPTIONAL { ?work wdt:P31 ?workClass }
   }
 
   # Get person's image
   OPTIONAL { wd:%s wdt:P18 ?image }
 
+  # Get person's date of death, if any
+  OPTIONAL { wd:%s wdt:P570 ?deathDate }
+
   SERVICE wikibase:label { bd:serviceParam wikibase:language "en" }
 }
 ORDER BY DESC(?year)
-`, wikidataID, wikidataID)
+`, wikidataID, wikidataID, wikidataID)
 
 	sparqlURL := "https://query.wikidata.org/sparql"
 	params := url.Values{}
@@ -204,6 +226,9 @@ ORDER BY DESC(?year)
 
 	awards := make([]WikidataAward, 0)
 	seenAwards := make(map[string]bool)
+	// A work can be an instance of several classes, each on its own row, so
+	// its type is settled across all rows rather than from the first one
+	workTypes := make(map[string]models.WorkType)
 
 	for _, binding := range sparqlResp.Results.Bindings {
 		// Get person info from first result
@@ -213,6 +238,19 @@ ORDER BY DESC(?year)
 		if personInfo.PhotoURL == "" && binding.Image.Value != "" {
 			personInfo.PhotoURL = binding.Image.Value
 		}
+		if personInfo.DeathDate.IsZero() && binding.DeathDate.Value != "" {
+			if t, err := time.Parse(time.RFC3339, binding.DeathDate.Value); err == nil {
+				personInfo.DeathDate = t
+			}
+		}
+
+		workID := strings.TrimPrefix(binding.Work.Value, wikidataEntityPrefix)
+		if workID != "" {
+			workType := classifyWork(strings.TrimPrefix(binding.WorkClass.Value, wikidataEntityPrefix))
+			if workType != models.WorkTypeOther || workTypes[workID] == "" {
+				workTypes[workID] = workType
+			}
+		}
 
 		// Parse award
 		awardID := binding.Award.Value
@@ -223,28 +261,173 @@ ORDER BY DESC(?year)
 			}
 		}
 
-		// Deduplicate by award + year (allows multiple wins in same category across different years)
-		dedupeKey := fmt.Sprintf("%s-%d", awardID, year)
+		// Deduplicate on the award's natural key (award + year + work), so two
+		// distinct wins of the same award in one year are both kept while the
+		// extra rows SPARQL returns for a single statement are collapsed
+		dedupeKey := fmt.Sprintf("%s-%d-%s", awardID, year, binding.WorkLabel.Value)
 		if seenAwards[dedupeKey] {
 			continue
 		}
 		seenAwards[dedupeKey] = true
 
 		award := WikidataAward{
-			AwardID:   awardID,
-			AwardName: binding.AwardLabel.Value,
-			Work:      binding.Work.Value,
-			Category:  binding.AwardLabel.Value, // Use award name as category
-			IsWinner:  true,                     // P166 is "award received", so these are wins
-			Year:      year,
+			StatementID: parseStatementID(binding.Statement.Value),
+			AwardID:     strings.TrimPrefix(awardID, wikidataEntityPrefix),
+			AwardName:   binding.AwardLabel.Value,
+			Work:        binding.WorkLabel.Value,
+			WorkID:      workID,
+			Category:    binding.AwardLabel.Value, // Use award name as category
+			IsWinner:    true,                     // P166 is "award received", so these are wins
+			Year:        year,
 		}
 
 		awards = append(awards, award)
 	}
 
+	for i := range awards {
+		awards[i].WorkType = workTypes[awards[i].WorkID]
+	}
+
 	return &personInfo, awards, nil
 }
 
+// GetAliases fetches a person's English alternate labels and birth names
+func (w *WikidataScraper) GetAliases(ctx context.Context, wikidataID string) ([]string, error) {
+	query := fmt.Sprintf(`
+SELECT DISTINCT ?alias WHERE {
+  {
+    wd:%s skos:altLabel ?alias .
+    FILTER(LANG(?alias) = "en")
+  }
+  UNION
+  {
+    wd:%s wdt:P1477 ?alias .
+  }
+}
+`, wikidataID, wikidataID)
+
+	sparqlURL := "https://query.wikidata.org/sparql"
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sparqlURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPARQL request: %w", err)
+	}
+	req.Header.Set("User-Agent", "EGOT-Tracker/1.0 (https://github.com/egot-tracker)")
+	req.Header.Set("Accept", "application/sparql-results+json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SPARQL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SPARQL endpoint returned status %d", resp.StatusCode)
+	}
+
+	var aliasResp struct {
+		Results struct {
+			Bindings []struct {
+				Alias SPARQLValue `json:"alias"`
+			} `json:"bindings"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&aliasResp); err != nil {
+		return nil, fmt.Errorf("failed to decode SPARQL response: %w", err)
+	}
+
+	aliases := make([]string, 0, len(aliasResp.Results.Bindings))
+	for _, binding := range aliasResp.Results.Bindings {
+		if alias := strings.TrimSpace(binding.Alias.Value); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	return aliases, nil
+}
+
+// WikidataWork is the work named on an award statement
+type WikidataWork struct {
+	WorkID string
+	Title  string
+	Type   models.WorkType
+}
+
+// GetStatementWorks looks up the work named on each of the given award
+// statements, keyed by statement ID. Statements naming no work are omitted.
+func (w *WikidataScraper) GetStatementWorks(ctx context.Context, statementIDs []string) (map[string]WikidataWork, error) {
+	works := make(map[string]WikidataWork)
+	if len(statementIDs) == 0 {
+		return works, nil
+	}
+
+	values := make([]string, len(statementIDs))
+	for i, id := range statementIDs {
+		// Statement IRIs use "-" where statement IDs use "$"
+		values[i] = "wds:" + strings.Replace(id, "$", "-", 1)
+	}
+
+	query := fmt.Sprintf(`
+SELECT ?statement ?work ?workLabel ?workClass WHERE {
+  VALUES ?statement { %s }
+  ?statement pq:P1686|pq:P1411|pq:P972 ?work .
+  OPTIONAL { ?work wdt:P31 ?workClass }
+  SERVICE wikibase:label { bd:serviceParam wikibase:language "en" }
+}
+`, strings.Join(values, " "))
+
+	sparqlURL := "https://query.wikidata.org/sparql"
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sparqlURL, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPARQL request: %w", err)
+	}
+	req.Header.Set("User-Agent", "EGOT-Tracker/1.0 (https://github.com/egot-tracker)")
+	req.Header.Set("Accept", "application/sparql-results+json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query SPARQL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("SPARQL endpoint returned status %d", resp.StatusCode)
+	}
+
+	var sparqlResp SPARQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&sparqlResp); err != nil {
+		return nil, fmt.Errorf("failed to decode SPARQL response: %w", err)
+	}
+
+	for _, binding := range sparqlResp.Results.Bindings {
+		statementID := parseStatementID(binding.Statement.Value)
+		workID := strings.TrimPrefix(binding.Work.Value, wikidataEntityPrefix)
+		if statementID == "" || workID == "" {
+			continue
+		}
+
+		workType := classifyWork(strings.TrimPrefix(binding.WorkClass.Value, wikidataEntityPrefix))
+		if existing, ok := works[statementID]; ok && workType == models.WorkTypeOther {
+			workType = existing.Type
+		}
+		works[statementID] = WikidataWork{
+			WorkID: workID,
+			Title:  binding.WorkLabel.Value,
+			Type:   workType,
+		}
+	}
+
+	return works, nil
+}
+
 // WikipediaSummaryResponse represents the response from Wikipedia REST API
 type WikipediaSummaryResponse struct {
 	Extract string `json:"extract"`
@@ -268,6 +451,9 @@ func (w *WikidataScraper) FetchWikipediaSummary(ctx context.Context, name string
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("no Wikipedia page for %s: %w", name, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("Wikipedia API returned status %d", resp.StatusCode)
 	}
@@ -280,18 +466,29 @@ func (w *WikidataScraper) FetchWikipediaSummary(ctx context.Context, name string
 	return summaryResp.Extract, nil
 }
 
-// FetchCelebrity searches for a celebrity and returns their data with awards
-func (w *WikidataScraper) FetchCelebrity(ctx context.Context, name string) (*models.Celebrity, []models.Award, error) {
+// ScrapeResult is everything scraped about a celebrity
+type ScrapeResult struct {
+	Celebrity models.Celebrity
+	Awards    []models.Award
+	// Aliases are the alternate names Wikidata knows the celebrity by
+	Aliases []models.CelebrityAlias
+	// Works are the works the celebrity's awards were for
+	Works []models.Work
+}
+
+// FetchCelebrity searches for a celebrity and returns their data with awards,
+// any alternate names Wikidata knows them by, and the works they won for
+func (w *WikidataScraper) FetchCelebrity(ctx context.Context, name string) (*ScrapeResult, error) {
 	// Step 1: Search for the person
 	personInfo, err := w.SearchPerson(ctx, name)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Step 2: Get their awards
 	fullInfo, wikidataAwards, err := w.GetPersonWithAwards(ctx, personInfo.WikidataID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Use the name from search if SPARQL didn't return it
@@ -302,7 +499,7 @@ func (w *WikidataScraper) FetchCelebrity(ctx context.Context, name string) (*mod
 	// Step 3: Fetch Wikipedia summary (required - skip if not found)
 	summary, err := w.FetchWikipediaSummary(ctx, fullInfo.Name)
 	if err != nil {
-		return nil, nil, fmt.Errorf("Wikipedia lookup failed for %s: %w", fullInfo.Name, err)
+		return nil, fmt.Errorf("Wikipedia lookup failed for %s: %w", fullInfo.Name, err)
 	}
 
 	// Step 4: Convert to our models
@@ -318,8 +515,14 @@ func (w *WikidataScraper) FetchCelebrity(ctx context.Context, name string) (*mod
 		celebrity.Summary.String = summary
 		celebrity.Summary.Valid = true
 	}
+	if !fullInfo.DeathDate.IsZero() {
+		celebrity.DeathDate = pgtype.Date{Time: fullInfo.DeathDate, Valid: true}
+	}
 
+	fetchedAt := pgtype.Timestamptz{Time: time.Now(), Valid: true}
 	awards := make([]models.Award, 0, len(wikidataAwards))
+	var works []models.Work
+	seenWorks := make(map[string]bool)
 	for _, wa := range wikidataAwards {
 		awardType := classifyAward(wa.AwardName)
 		if awardType == "" {
@@ -327,16 +530,82 @@ func (w *WikidataScraper) FetchCelebrity(ctx context.Context, name string) (*mod
 		}
 
 		award := models.Award{
-			Type:     awardType,
-			Year:     wa.Year,
-			Work:     wa.Work,
-			Category: wa.Category,
-			IsWinner: wa.IsWinner,
+			Type:           awardType,
+			Year:           wa.Year,
+			Work:           wa.Work,
+			Category:       wa.Category,
+			IsWinner:       wa.IsWinner,
+			Source:         models.AwardSourceWikidata,
+			FetchedAt:      fetchedAt,
+			ScraperVersion: pgtype.Text{String: ScraperVersion, Valid: true},
+		}
+		if wa.StatementID != "" {
+			award.WikidataStatementID = pgtype.Text{String: wa.StatementID, Valid: true}
+		}
+		if wa.AwardID != "" {
+			award.WikidataAwardID = pgtype.Text{String: wa.AwardID, Valid: true}
+		}
+		if wa.WorkID != "" {
+			award.WikidataWorkID = pgtype.Text{String: wa.WorkID, Valid: true}
+			if !seenWorks[wa.WorkID] {
+				seenWorks[wa.WorkID] = true
+				works = append(works, models.Work{
+					WikidataID: wa.WorkID,
+					Title:      wa.Work,
+					Type:       wa.WorkType,
+				})
+			}
 		}
 		awards = append(awards, award)
 	}
 
-	return celebrity, awards, nil
+	// Step 5: Fetch alternate names (optional - a failure just means no aliases)
+	var aliases []models.CelebrityAlias
+	if names, err := w.GetAliases(ctx, personInfo.WikidataID); err == nil {
+		for _, alias := range names {
+			if strings.EqualFold(alias, celebrity.Name) {
+				continue
+			}
+			aliases = append(aliases, models.CelebrityAlias{
+				Alias:  alias,
+				Source: models.AliasSourceWikidata,
+			})
+		}
+	}
+
+	return &ScrapeResult{Celebrity: *celebrity, Awards: awards, Aliases: aliases, Works: works}, nil
+}
+
+// workClasses maps Wikidata classes (P31 values) of award-winning works to
+// work types. Works of any other class are typed as "other".
+var workClasses = map[string]models.WorkType{
+	"Q11424":    models.WorkTypeFilm,   // film
+	"Q506240":   models.WorkTypeFilm,   // television film
+	"Q24862":    models.WorkTypeFilm,   // short film
+	"Q202866":   models.WorkTypeFilm,   // animated film
+	"Q93204":    models.WorkTypeFilm,   // documentary film
+	"Q29168811": models.WorkTypeFilm,   // animated feature film
+	"Q5398426":  models.WorkTypeSeries, // television series
+	"Q1259759":  models.WorkTypeSeries, // miniseries
+	"Q581714":   models.WorkTypeSeries, // animated television series
+	"Q15416":    models.WorkTypeSeries, // television program
+	"Q21191270": models.WorkTypeSeries, // television series episode
+	"Q482994":   models.WorkTypeAlbum,  // album
+	"Q208569":   models.WorkTypeAlbum,  // studio album
+	"Q209939":   models.WorkTypeAlbum,  // live album
+	"Q4176708":  models.WorkTypeAlbum,  // soundtrack album
+	"Q222910":   models.WorkTypeAlbum,  // compilation album
+	"Q25379":    models.WorkTypePlay,   // play
+	"Q2743":     models.WorkTypePlay,   // musical
+	"Q7777570":  models.WorkTypePlay,   // theatrical production
+}
+
+// classifyWork determines the work type from a Wikidata class QID
+func classifyWork(classID string) models.WorkType {
+	if workType, ok := workClasses[classID]; ok {
+		return workType
+	}
+	return models.WorkTypeOther
 }
 
 // classifyAward determines the EGOT award type from the award name
@@ -359,6 +628,17 @@ func classifyAward(awardName string) models.AwardType {
 	return ""
 }
 
+// parseStatementID converts a statement IRI such as
+// http://www.wikidata.org/entity/statement/Q42-F078E5B3-...
+// into the canonical statement ID Q42$F078E5B3-...
+func parseStatementID(iri string) string {
+	id := strings.TrimPrefix(iri, wikidataStatementPrefix)
+	if id == "" || id == iri {
+		return ""
+	}
+	return strings.Replace(id, "-", "$", 1)
+}
+
 // slugify converts a name to a URL-friendly slug
 func slugify(name string) string {
 	slug := strings.ToLower(name)
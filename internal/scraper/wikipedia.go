@@ -19,7 +19,8 @@ type WikipediaScraper struct {
 func NewWikipediaScraper() *WikipediaScraper {
 	return &WikipediaScraper{
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: newTransport(),
 		},
 	}
 }
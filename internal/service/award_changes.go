@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// recordAwardChanges records the EGOT milestones a celebrity reached between
+// two sets of awards and queues webhooks for their watchers. Every award
+// writer calls it in the transaction that wrote the awards. Milestones are
+// marked as initial imports when initialImport is set, i.e. the celebrity
+// was first saved by the write, so they happened before we tracked them.
+func recordAwardChanges(ctx context.Context, repos *repository.Repositories, celebrity models.Celebrity, before, after []models.Award, initialImport bool) error {
+	changes := models.DiffAwards(before, after)
+	if err := repos.Events.CreateBatch(ctx, changes.EGOTEvents(celebrity, initialImport)); err != nil {
+		return err
+	}
+	return enqueueAwardWebhooks(ctx, repos, celebrity, changes)
+}
+
+// TrackAwardChanges calls write, which changes the awards of the given
+// celebrities in the transaction repos is bound to, then records the
+// milestones reached and queues webhooks as a scrape would. It lets bulk
+// writers such as the snapshot import share the scrape's change handling.
+func TrackAwardChanges(ctx context.Context, repos *repository.Repositories, celebrityIDs []pgtype.UUID, write func() error) error {
+	// Milestones of celebrities the write creates happened before we
+	// tracked them
+	existing, err := repos.Celebrities.FindByIDs(ctx, celebrityIDs)
+	if err != nil {
+		return err
+	}
+	tracked := make(map[pgtype.UUID]bool, len(existing))
+	for _, c := range existing {
+		tracked[c.ID] = true
+	}
+
+	before, err := awardsByCelebrity(ctx, repos, celebrityIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := write(); err != nil {
+		return err
+	}
+
+	after, err := awardsByCelebrity(ctx, repos, celebrityIDs)
+	if err != nil {
+		return err
+	}
+	celebrities, err := repos.Celebrities.FindByIDs(ctx, celebrityIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range celebrities {
+		if err := recordAwardChanges(ctx, repos, c, before[c.ID], after[c.ID], !tracked[c.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// awardsByCelebrity returns the awards of the given celebrities keyed by
+// celebrity
+func awardsByCelebrity(ctx context.Context, repos *repository.Repositories, celebrityIDs []pgtype.UUID) (map[pgtype.UUID][]models.Award, error) {
+	awards, err := repos.Awards.FindByCelebrityIDs(ctx, celebrityIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byCelebrity := make(map[pgtype.UUID][]models.Award, len(celebrityIDs))
+	for _, a := range awards {
+		byCelebrity[a.CelebrityID] = append(byCelebrity[a.CelebrityID], a)
+	}
+	return byCelebrity, nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"egot-tracker/internal/ical"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidCalendarToken  = newError(KindUnauthorized, "invalid_calendar_token", "invalid calendar token")
+	ErrCalendarTokenRequired = invalidField("calendar_token_required", "key",
+		"API keys are not accepted in calendar URLs; subscribe with the watchlist's calendar token")
+)
+
+type CalendarService struct {
+	ceremonyRepo  *repository.CeremonyRepository
+	watchlistRepo *repository.WatchlistRepository
+	siteURL       string
+}
+
+func NewCalendarService(ceremonyRepo *repository.CeremonyRepository, watchlistRepo *repository.WatchlistRepository, siteURL string) *CalendarService {
+	return &CalendarService{
+		ceremonyRepo:  ceremonyRepo,
+		watchlistRepo: watchlistRepo,
+		siteURL:       siteURL,
+	}
+}
+
+// WatchlistCelebrities returns the IDs of the celebrities on the watchlist a
+// calendar token was issued for
+func (s *CalendarService) WatchlistCelebrities(ctx context.Context, token string) ([]pgtype.UUID, error) {
+	if !strings.HasPrefix(token, calendarTokenPrefix) {
+		return nil, ErrInvalidCalendarToken
+	}
+
+	watchlist, err := s.watchlistRepo.FindByCalendarTokenHash(ctx, hashToken(token))
+	if errors.Is(err, repository.ErrWatchlistNotFound) {
+		return nil, ErrInvalidCalendarToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	celebrities, err := s.watchlistRepo.FindCelebrities(ctx, watchlist.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]pgtype.UUID, len(celebrities))
+	for i, c := range celebrities {
+		ids[i] = c.ID
+	}
+	return ids, nil
+}
+
+// UpcomingCeremonies returns a calendar of upcoming ceremonies. Each event
+// lists the tracked nominees that pass the filter; with a non-empty filter,
+// ceremonies without such nominees are left out.
+func (s *CalendarService) UpcomingCeremonies(ctx context.Context, filter models.CeremonyFilter) (*ical.Calendar, error) {
+	ceremonies, err := s.ceremonyRepo.FindUpcoming(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &ical.Calendar{
+		Name:        "EGOT Tracker: Award Ceremonies",
+		Description: "Upcoming Emmy, Grammy, Oscar and Tony ceremonies",
+	}
+	if !filter.IsEmpty() {
+		calendar.Name = "EGOT Tracker: Ceremonies to Watch"
+	}
+
+	for _, c := range ceremonies {
+		var nominees []models.CeremonyNominee
+		for _, n := range c.Nominees {
+			if filter.Matches(n) {
+				nominees = append(nominees, n)
+			}
+		}
+		if !filter.IsEmpty() && len(nominees) == 0 {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         fmt.Sprintf("%s-%s@egot-tracker", strings.ToLower(string(c.Type)), c.Date.Time.Format("2006-01-02")),
+			Summary:     ceremonyTitle(c),
+			Description: describeNominees(c.Type, nominees),
+			URL:         s.ceremonyURL(c),
+			Date:        c.Date.Time,
+		})
+	}
+
+	return calendar, nil
+}
+
+// ceremonyTitle returns the ceremony's own name, or e.g. "2027 Tony Awards"
+func ceremonyTitle(c models.Ceremony) string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return fmt.Sprintf("%d %s Awards", c.Year, c.Type)
+}
+
+// describeNominees lists nominees one per line with their EGOT progress
+func describeNominees(awardType models.AwardType, nominees []models.CeremonyNominee) string {
+	if len(nominees) == 0 {
+		return "No tracked nominees"
+	}
+
+	var b strings.Builder
+	b.WriteString("Tracked nominees:")
+	for _, n := range nominees {
+		fmt.Fprintf(&b, "\n- %s (%d/4", n.Celebrity.Name, n.Celebrity.EGOTWinCount)
+		if n.CompletesEGOT(awardType) {
+			b.WriteString(", a win completes the EGOT")
+		}
+		fmt.Fprintf(&b, "): %s", describeWork(n.Category, n.Work))
+	}
+	return b.String()
+}
+
+// ceremonyURL links ceremonies tracked by the Oscar race to its page
+func (s *CalendarService) ceremonyURL(c models.Ceremony) string {
+	if c.InOscarRace {
+		return fmt.Sprintf("%s/oscar-race/%d", s.siteURL, c.Year)
+	}
+	return s.siteURL
+}
@@ -3,29 +3,54 @@ package service
 import (
 	"context"
 	"errors"
-	"log"
+	"strconv"
+	"strings"
+	"time"
 
+	"egot-tracker/internal/logging"
+	"egot-tracker/internal/metrics"
 	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
 	"egot-tracker/internal/repository"
 	"egot-tracker/internal/scraper"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-var ErrCelebrityNotFound = errors.New("celebrity not found")
+var (
+	ErrCelebrityNotFound = newError(KindNotFound, "celebrity_not_found", "celebrity not found")
+	ErrAliasExists       = newError(KindConflict, "alias_exists", "alias already exists")
+	ErrInvalidAlias      = invalidField("invalid_alias", "alias", "alias is required")
+)
+
+// searchCache counts searches answered from the database (hits) and those
+// that had to scrape Wikidata (misses)
+var searchCache = metrics.NewCounter(
+	"egot_celebrity_search_cache_total",
+	"Celebrity searches by whether they were answered from the database (hit) or scraped (miss).",
+	"result",
+)
 
 type CelebrityService struct {
 	celebrityRepo *repository.CelebrityRepository
 	awardRepo     *repository.AwardRepository
+	aliasRepo     *repository.AliasRepository
+	uow           *repository.UnitOfWork
 	scraper       *scraper.WikidataScraper
 }
 
 func NewCelebrityService(
 	celebrityRepo *repository.CelebrityRepository,
 	awardRepo *repository.AwardRepository,
+	aliasRepo *repository.AliasRepository,
+	uow *repository.UnitOfWork,
 	scraper *scraper.WikidataScraper,
 ) *CelebrityService {
 	return &CelebrityService{
 		celebrityRepo: celebrityRepo,
 		awardRepo:     awardRepo,
+		aliasRepo:     aliasRepo,
+		uow:           uow,
 		scraper:       scraper,
 	}
 }
@@ -43,48 +68,110 @@ func (s *CelebrityService) SearchCelebrity(ctx context.Context, name string) (*m
 
 	// Step 2: If found in DB, fetch awards and return
 	if celebrity != nil {
+		searchCache.Inc("hit")
 		awards, err := s.awardRepo.FindByCelebrityID(ctx, celebrity.ID)
 		if err != nil {
 			return nil, err
 		}
-		if awards == nil {
-			awards = []models.Award{}
-		}
-		return &models.CelebrityWithAwards{
-			Celebrity: *celebrity,
-			Awards:    awards,
-		}, nil
+		return models.NewCelebrityWithAwards(*celebrity, awards), nil
 	}
 
 	// Step 3: Not in DB - scrape from Wikidata
-	log.Printf("Celebrity not in DB, fetching from Wikidata: %s", name)
+	searchCache.Inc("miss")
+	logger := logging.FromContext(ctx).With("search", name)
+	logger.Info("celebrity not in database, fetching from Wikidata")
 
-	scrapedCelebrity, scrapedAwards, err := s.scraper.FetchCelebrity(ctx, name)
-	if err != nil {
-		log.Printf("Failed to fetch from Wikidata: %v", err)
+	scraped, err := s.scraper.FetchCelebrity(ctx, name)
+	if errors.Is(err, scraper.ErrNotFound) {
+		logger.Info("celebrity not found on Wikidata", "error", err)
 		return nil, ErrCelebrityNotFound
 	}
-
-	// Step 4: Save celebrity to database
-	savedCelebrity, err := s.celebrityRepo.Create(ctx, scrapedCelebrity)
 	if err != nil {
-		log.Printf("Failed to save celebrity: %v", err)
-		return nil, err
+		logger.Warn("failed to fetch from Wikidata", "error", err)
+		return nil, ErrUpstreamUnavailable
 	}
 
-	// Step 5: Save awards to database
-	savedAwards, err := s.awardRepo.CreateBatch(ctx, savedCelebrity.ID, scrapedAwards)
+	// Step 4: Save celebrity, works, awards and aliases
+	saved, awards, err := s.saveScrape(ctx, scraped, nil)
 	if err != nil {
-		log.Printf("Failed to save awards: %v", err)
+		logger.Error("failed to save celebrity", "error", err)
 		return nil, err
 	}
 
-	log.Printf("Saved %s with %d awards from Wikidata", savedCelebrity.Name, len(savedAwards))
+	logger.Info("saved celebrity from Wikidata",
+		"celebrity", saved.Name,
+		"awards", len(awards),
+		"aliases", len(scraped.Aliases),
+	)
+
+	return models.NewCelebrityWithAwards(*saved, awards), nil
+}
+
+// saveScrape saves a scraped celebrity with their works, awards and aliases
+// in one transaction, so a failure never leaves a celebrity without awards.
+// The scrape is saved to existing if given, and otherwise to the celebrity
+// stored under the scraped name, who is created if there is none. Changes
+// to the awards are recorded as milestones and queued for watchers in the
+// same transaction.
+func (s *CelebrityService) saveScrape(ctx context.Context, scraped *scraper.ScrapeResult, existing *models.Celebrity) (*models.Celebrity, []models.Award, error) {
+	var saved *models.Celebrity
+	var awards []models.Award
+	err := s.uow.WithinTx(ctx, func(repos *repository.Repositories) error {
+		saved = existing
+		created := false
+		if saved == nil {
+			// The search may have been for an alternate name of someone we
+			// already store under their Wikidata label
+			found, err := repos.Celebrities.FindByExactName(ctx, scraped.Celebrity.Name)
+			switch {
+			case err == nil:
+				saved = found
+			case errors.Is(err, repository.ErrCelebrityNotFound):
+				saved, err = repos.Celebrities.Create(ctx, &scraped.Celebrity)
+				if err != nil {
+					return err
+				}
+				created = true
+			default:
+				return err
+			}
+		}
+
+		// Remember the awards the celebrity already had, so that milestones
+		// can be detected and watchers told what changed
+		before, err := repos.Awards.FindByCelebrityID(ctx, saved.ID)
+		if err != nil {
+			return err
+		}
+
+		// Works are saved first so the awards can be linked to them
+		if err := repos.Works.UpsertBatch(ctx, scraped.Works); err != nil {
+			return err
+		}
+		if _, err := repos.Awards.CreateBatch(ctx, saved.ID, scraped.Awards); err != nil {
+			return err
+		}
+		if err := repos.Aliases.CreateBatch(ctx, saved.ID, scraped.Aliases); err != nil {
+			return err
+		}
+		if err := repos.Celebrities.Touch(ctx, saved.ID); err != nil {
+			return err
+		}
+
+		// The celebrity may have awards beyond the ones just scraped
+		awards, err = repos.Awards.FindByCelebrityID(ctx, saved.ID)
+		if err != nil {
+			return err
+		}
 
-	return &models.CelebrityWithAwards{
-		Celebrity: *savedCelebrity,
-		Awards:    savedAwards,
-	}, nil
+		// Milestones found when the celebrity is first saved happened before
+		// we tracked them. Once tracked, a refresh reports what is new.
+		return recordAwardChanges(ctx, repos, *saved, before, awards, created)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return saved, awards, nil
 }
 
 // Autocomplete returns celebrities matching the query from the local database
@@ -95,26 +182,181 @@ func (s *CelebrityService) Autocomplete(ctx context.Context, query string, limit
 	return s.celebrityRepo.Search(ctx, query, limit)
 }
 
-// GetCloseToEGOT returns celebrities with 3 of 4 EGOT awards
-func (s *CelebrityService) GetCloseToEGOT(ctx context.Context, limit int) ([]models.CelebrityWithEGOTProgress, error) {
-	if limit <= 0 {
-		limit = 50
+// BrowseResult is a page of browse results with facet counts for the filter
+type BrowseResult struct {
+	pagination.Page[models.CelebrityBrowseItem]
+	Facets *models.CelebrityFacets `json:"facets"`
+}
+
+// Browse returns a page of celebrities matching the filter, with facet counts
+func (s *CelebrityService) Browse(ctx context.Context, filter models.CelebrityFilter, page pagination.Params) (*BrowseResult, error) {
+	results, err := s.BrowsePage(ctx, filter, page)
+	if err != nil {
+		return nil, err
 	}
-	return s.celebrityRepo.FindCloseToEGOT(ctx, limit)
+
+	facets, err := s.celebrityRepo.Facets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BrowseResult{Page: results, Facets: facets}, nil
 }
 
-// GetEGOTWinners returns celebrities with all 4 EGOT awards
-func (s *CelebrityService) GetEGOTWinners(ctx context.Context, limit int) ([]models.CelebrityWithEGOTProgress, error) {
-	if limit <= 0 {
-		limit = 50
+// BrowsePage returns a page of celebrities matching the filter, without facets
+func (s *CelebrityService) BrowsePage(ctx context.Context, filter models.CelebrityFilter, page pagination.Params) (pagination.Page[models.CelebrityBrowseItem], error) {
+	celebrities, total, err := s.celebrityRepo.Browse(ctx, filter, page)
+	if err != nil {
+		return pagination.Page[models.CelebrityBrowseItem]{}, err
 	}
-	return s.celebrityRepo.FindEGOTWinners(ctx, limit)
+	return pagination.NewPage(celebrities, total, page, browseCursor(filter.Sort)), nil
 }
 
-// GetNoAwards returns celebrities with no awards
-func (s *CelebrityService) GetNoAwards(ctx context.Context, limit int) ([]models.Celebrity, error) {
-	if limit <= 0 {
-		limit = 50
+// browseCursor returns a function building the cursor for a sort order
+func browseCursor(sort models.CelebritySort) func(models.CelebrityBrowseItem) pagination.Cursor {
+	return func(c models.CelebrityBrowseItem) pagination.Cursor {
+		cursor := pagination.Cursor{ID: uuidString(c.ID)}
+		switch sort {
+		case models.SortByRecentWin:
+			year := 0
+			if c.LatestWinYear != nil {
+				year = *c.LatestWinYear
+			}
+			cursor.Key = strconv.Itoa(year)
+		case models.SortByTotalWins:
+			cursor.Key = strconv.Itoa(c.TotalWins)
+		case models.SortByUpdated:
+			updated := time.Unix(0, 0).UTC()
+			if c.LastUpdated.Valid {
+				updated = c.LastUpdated.Time
+			}
+			cursor.Key = updated.Format(time.RFC3339Nano)
+		default:
+			cursor.Key = c.Name
+		}
+		return cursor
+	}
+}
+
+// GetCloseToEGOT returns a page of celebrities with 3 of 4 EGOT awards
+func (s *CelebrityService) GetCloseToEGOT(ctx context.Context, page pagination.Params) (pagination.Page[models.CelebrityWithEGOTProgress], error) {
+	return s.browseByWinCount(ctx, 3, page)
+}
+
+// GetEGOTWinners returns a page of celebrities with all 4 EGOT awards
+func (s *CelebrityService) GetEGOTWinners(ctx context.Context, page pagination.Params) (pagination.Page[models.CelebrityWithEGOTProgress], error) {
+	return s.browseByWinCount(ctx, 4, page)
+}
+
+// browseByWinCount returns celebrities with exactly count distinct EGOT wins,
+// ordered by name
+func (s *CelebrityService) browseByWinCount(ctx context.Context, count int, page pagination.Params) (pagination.Page[models.CelebrityWithEGOTProgress], error) {
+	results, err := s.BrowsePage(ctx, models.CelebrityFilter{
+		MinWins: &count,
+		MaxWins: &count,
+		Sort:    models.SortByName,
+	}, page)
+	if err != nil {
+		return pagination.Page[models.CelebrityWithEGOTProgress]{}, err
+	}
+	return pagination.Map(results, func(c models.CelebrityBrowseItem) models.CelebrityWithEGOTProgress {
+		return c.CelebrityWithEGOTProgress
+	}), nil
+}
+
+// GetNoAwards returns a page of celebrities with no awards, least recently
+// updated first
+func (s *CelebrityService) GetNoAwards(ctx context.Context, page pagination.Params) (pagination.Page[models.Celebrity], error) {
+	hasAwards := false
+	results, err := s.BrowsePage(ctx, models.CelebrityFilter{
+		HasAwards: &hasAwards,
+		Sort:      models.SortByUpdated,
+	}, page)
+	if err != nil {
+		return pagination.Page[models.Celebrity]{}, err
+	}
+	return pagination.Map(results, func(c models.CelebrityBrowseItem) models.Celebrity {
+		return c.Celebrity
+	}), nil
+}
+
+// GetEGOTWatch returns the pending nominations that would complete someone's
+// EGOT or take them to 3/4, grouped by upcoming ceremony in date order
+func (s *CelebrityService) GetEGOTWatch(ctx context.Context) ([]models.UpcomingCeremony, error) {
+	nominations, err := s.awardRepo.FindPendingNominations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nominations arrive in ceremony order, so a change of ceremony starts a
+	// new group
+	ceremonies := []models.UpcomingCeremony{}
+	for _, n := range nominations {
+		last := len(ceremonies) - 1
+		if last < 0 || !sameCeremony(ceremonies[last], n.Award) {
+			ceremonies = append(ceremonies, models.UpcomingCeremony{
+				Type:         n.Award.Type,
+				Year:         n.Award.Year,
+				CeremonyDate: n.Award.CeremonyDate,
+			})
+			last++
+		}
+		ceremonies[last].Nominations = append(ceremonies[last].Nominations, n)
+	}
+
+	return ceremonies, nil
+}
+
+// sameCeremony reports whether an award is presented at the ceremony
+func sameCeremony(ceremony models.UpcomingCeremony, award models.Award) bool {
+	if ceremony.Type != award.Type || ceremony.Year != award.Year {
+		return false
+	}
+	if ceremony.CeremonyDate.Valid != award.CeremonyDate.Valid {
+		return false
+	}
+	return !award.CeremonyDate.Valid || ceremony.CeremonyDate.Time.Equal(award.CeremonyDate.Time)
+}
+
+// uuidString formats a UUID in its canonical hyphenated form
+func uuidString(id pgtype.UUID) string {
+	value, _ := id.Value()
+	s, _ := value.(string)
+	return s
+}
+
+// GetAliases returns the alternate names stored for a celebrity
+func (s *CelebrityService) GetAliases(ctx context.Context, celebrityID pgtype.UUID) ([]models.CelebrityAlias, error) {
+	if _, err := s.celebrityRepo.FindByID(ctx, celebrityID); err != nil {
+		if errors.Is(err, repository.ErrCelebrityNotFound) {
+			return nil, ErrCelebrityNotFound
+		}
+		return nil, err
+	}
+	return s.aliasRepo.FindByCelebrityID(ctx, celebrityID)
+}
+
+// AddAlias manually records an alternate name for a celebrity
+func (s *CelebrityService) AddAlias(ctx context.Context, celebrityID pgtype.UUID, alias string) (*models.CelebrityAlias, error) {
+	alias = strings.TrimSpace(alias)
+	if alias == "" {
+		return nil, ErrInvalidAlias
+	}
+
+	if _, err := s.celebrityRepo.FindByID(ctx, celebrityID); err != nil {
+		if errors.Is(err, repository.ErrCelebrityNotFound) {
+			return nil, ErrCelebrityNotFound
+		}
+		return nil, err
+	}
+
+	created, err := s.aliasRepo.Create(ctx, &models.CelebrityAlias{
+		CelebrityID: celebrityID,
+		Alias:       alias,
+		Source:      models.AliasSourceManual,
+	})
+	if errors.Is(err, repository.ErrAliasExists) {
+		return nil, ErrAliasExists
 	}
-	return s.celebrityRepo.FindNoAwards(ctx, limit)
+	return created, err
 }
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"egot-tracker/internal/graph"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrNoPath = newError(KindNotFound, "no_path", "no path between celebrities")
+
+// CollaboratorService answers questions about who shares award-winning works.
+// It keeps the collaborator graph in memory, rebuilding it on first use after
+// Invalidate is called.
+type CollaboratorService struct {
+	celebrityRepo *repository.CelebrityRepository
+	workRepo      *repository.WorkRepository
+
+	mu    sync.Mutex
+	graph *graph.CollaboratorGraph
+}
+
+func NewCollaboratorService(celebrityRepo *repository.CelebrityRepository, workRepo *repository.WorkRepository) *CollaboratorService {
+	return &CollaboratorService{
+		celebrityRepo: celebrityRepo,
+		workRepo:      workRepo,
+	}
+}
+
+// Invalidate discards the graph so it is rebuilt from the awards table on
+// next use. Call it whenever awards or works change.
+func (s *CollaboratorService) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.graph = nil
+}
+
+// currentGraph returns the collaborator graph, building it if needed
+func (s *CollaboratorService) currentGraph(ctx context.Context) (*graph.CollaboratorGraph, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.graph != nil {
+		return s.graph, nil
+	}
+
+	wins, err := s.workRepo.FindAllWins(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.graph = graph.New(wins)
+	return s.graph, nil
+}
+
+// GetCollaborators returns everyone who won awards for the same works as
+// the celebrity
+func (s *CollaboratorService) GetCollaborators(ctx context.Context, celebrityID pgtype.UUID) ([]models.Collaborator, error) {
+	if _, err := s.findCelebrity(ctx, celebrityID); err != nil {
+		return nil, err
+	}
+
+	g, err := s.currentGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return g.Collaborators(celebrityID.Bytes), nil
+}
+
+// GetPath returns the shortest chain of shared award-winning works between
+// two celebrities
+func (s *CollaboratorService) GetPath(ctx context.Context, fromID, toID pgtype.UUID) (*models.CollaborationPath, error) {
+	from, err := s.findCelebrity(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.findCelebrity(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := s.currentGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, ok := g.ShortestPath(fromID.Bytes, toID.Bytes)
+	if !ok {
+		return nil, ErrNoPath
+	}
+
+	return &models.CollaborationPath{
+		From:    *from,
+		To:      *to,
+		Degrees: len(steps),
+		Steps:   steps,
+	}, nil
+}
+
+func (s *CollaboratorService) findCelebrity(ctx context.Context, id pgtype.UUID) (*models.Celebrity, error) {
+	celebrity, err := s.celebrityRepo.FindByID(ctx, id)
+	if errors.Is(err, repository.ErrCelebrityNotFound) {
+		return nil, ErrCelebrityNotFound
+	}
+	return celebrity, err
+}
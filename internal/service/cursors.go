@@ -0,0 +1,77 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+)
+
+// Cursor scopes of the paginated lists. A cursor only decodes for the list
+// and sort order it was issued by, so one replayed elsewhere is rejected as
+// invalid input instead of being misread by a query.
+var (
+	CloseToEGOTCursors   = pagination.Scope{Name: "close-to-egot", Key: pagination.TextKey, HasID: true}
+	EGOTWinnersCursors   = pagination.Scope{Name: "egot-winners", Key: pagination.TextKey, HasID: true}
+	NoAwardsCursors      = pagination.Scope{Name: "no-awards", Key: pagination.TimeKey, HasID: true}
+	CeremonyYearsCursors = pagination.Scope{Name: "oscar-years", Key: pagination.IntKey}
+	DeliveryCursors      = pagination.Scope{Name: "deliveries", Key: pagination.TimeKey, HasID: true}
+	EventCursors         = pagination.Scope{Name: "events", Key: pagination.TimeKey, HasID: true}
+)
+
+// BrowseCursors is the cursor scope of celebrity browsing with a filter and
+// sort order. The scope includes a hash of the filter, so a cursor is
+// rejected under a different filter rather than resumed from an unrelated
+// position.
+func BrowseCursors(filter models.CelebrityFilter) pagination.Scope {
+	scope := pagination.Scope{
+		Name:  "celebrities:" + string(filter.Sort) + ":" + filterHash(filter),
+		Key:   pagination.TextKey,
+		HasID: true,
+	}
+	switch filter.Sort {
+	case models.SortByRecentWin, models.SortByTotalWins:
+		scope.Key = pagination.IntKey
+	case models.SortByUpdated:
+		scope.Key = pagination.TimeKey
+	}
+	return scope
+}
+
+// filterHash identifies a browse filter regardless of how it was written:
+// missing award types are compared as a set
+func filterHash(filter models.CelebrityFilter) string {
+	optional := func(n *int) string {
+		if n == nil {
+			return ""
+		}
+		return strconv.Itoa(*n)
+	}
+
+	missing := make([]string, len(filter.Missing))
+	for i, t := range filter.Missing {
+		missing[i] = string(t)
+	}
+	slices.Sort(missing)
+	missing = slices.Compact(missing)
+
+	hasAwards := ""
+	if filter.HasAwards != nil {
+		hasAwards = strconv.FormatBool(*filter.HasAwards)
+	}
+
+	normalised := fmt.Sprintf("min=%s;max=%s;missing=%q;from=%s;to=%s;status=%s;has_awards=%s",
+		optional(filter.MinWins), optional(filter.MaxWins), missing,
+		optional(filter.WonFrom), optional(filter.WonTo), filter.Status, hasAwards)
+	sum := sha256.Sum256([]byte(normalised))
+	return hex.EncodeToString(sum[:8])
+}
+
+// LeaderboardCursors is the cursor scope of a leaderboard
+func LeaderboardCursors(metric models.LeaderboardMetric) pagination.Scope {
+	return pagination.Scope{Name: "leaderboard:" + string(metric), Key: pagination.IntKey, HasID: true}
+}
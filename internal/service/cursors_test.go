@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+)
+
+func TestBrowseCursorsRejectOtherFilters(t *testing.T) {
+	three, four := 3, 4
+	base := models.CelebrityFilter{
+		MinWins: &three,
+		Missing: []models.AwardType{models.AwardTypeTony, models.AwardTypeOscar},
+		Sort:    models.SortByName,
+	}
+	cursor := pagination.Cursor{
+		Scope: BrowseCursors(base).Name,
+		Key:   "Viola Davis",
+		ID:    "7b1a0c2e-5d4f-4e3a-9b8c-1d2e3f4a5b6c",
+	}.Encode()
+
+	tests := []struct {
+		name   string
+		filter func(f models.CelebrityFilter) models.CelebrityFilter
+		valid  bool
+	}{
+		{"same filter", func(f models.CelebrityFilter) models.CelebrityFilter { return f }, true},
+		{"missing types reordered and repeated", func(f models.CelebrityFilter) models.CelebrityFilter {
+			f.Missing = []models.AwardType{models.AwardTypeOscar, models.AwardTypeTony, models.AwardTypeOscar}
+			return f
+		}, true},
+		{"different minimum", func(f models.CelebrityFilter) models.CelebrityFilter {
+			f.MinWins = &four
+			return f
+		}, false},
+		{"minimum moved to maximum", func(f models.CelebrityFilter) models.CelebrityFilter {
+			f.MinWins, f.MaxWins = nil, &three
+			return f
+		}, false},
+		{"fewer missing types", func(f models.CelebrityFilter) models.CelebrityFilter {
+			f.Missing = f.Missing[:1]
+			return f
+		}, false},
+		{"status added", func(f models.CelebrityFilter) models.CelebrityFilter {
+			f.Status = models.LifeStatusLiving
+			return f
+		}, false},
+		{"different sort", func(f models.CelebrityFilter) models.CelebrityFilter {
+			f.Sort = models.SortByUpdated
+			return f
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BrowseCursors(tt.filter(base)).Decode(cursor)
+			if tt.valid && err != nil {
+				t.Errorf("Decode: %v", err)
+			}
+			if !tt.valid && !errors.Is(err, pagination.ErrInvalidCursor) {
+				t.Errorf("Decode = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+// ErrorKind classifies domain errors. The HTTP layer maps each kind to a
+// status code, so services never deal in HTTP statuses.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	// KindUnavailable is a failure of a service we depend on, such as
+	// Wikidata, that may succeed if retried
+	KindUnavailable
+)
+
+// Error is a domain error with a stable, machine-readable code
+type Error struct {
+	Kind ErrorKind
+	// Code identifies the error to clients and never changes, e.g.
+	// "celebrity_not_found"
+	Code    string
+	Message string
+	// Fields maps invalid input, such as a body field or query parameter, to
+	// what is wrong with it
+	Fields map[string]string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is matches errors by code, so errors.Is(err, ErrInvalidInput) holds for
+// errors built by InvalidInput
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+func newError(kind ErrorKind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// invalidField is an invalid input error with a specific code
+func invalidField(code, field, problem string) *Error {
+	return &Error{Kind: KindInvalid, Code: code, Message: problem, Fields: map[string]string{field: problem}}
+}
+
+var (
+	ErrInternal            = newError(KindInternal, "internal_error", "internal server error")
+	ErrInvalidInput        = newError(KindInvalid, "invalid_input", "invalid input")
+	ErrAPIKeyRequired      = newError(KindUnauthorized, "api_key_required", "api key required")
+	ErrInvalidAPIKey       = newError(KindUnauthorized, "invalid_api_key", "invalid api key")
+	ErrAdminRequired       = newError(KindForbidden, "admin_required", "an admin api key is required")
+	ErrNomineeNotFound     = newError(KindNotFound, "nominee_not_found", "nominee not found")
+	ErrFeedNotFound        = newError(KindNotFound, "feed_not_found", "feed not found")
+	ErrUpstreamUnavailable = newError(KindUnavailable, "upstream_unavailable", "Wikidata could not be reached, try again later")
+)
+
+// InvalidInput reports a problem with one field of the request, which is
+// named in the error's field details
+func InvalidInput(field, problem string) *Error {
+	return &Error{
+		Kind:    KindInvalid,
+		Code:    ErrInvalidInput.Code,
+		Message: problem,
+		Fields:  map[string]string{field: problem},
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/repository"
+)
+
+type EventService struct {
+	eventRepo *repository.EventRepository
+}
+
+func NewEventService(eventRepo *repository.EventRepository) *EventService {
+	return &EventService{eventRepo: eventRepo}
+}
+
+// GetEvents returns a page of EGOT milestone events, newest first
+func (s *EventService) GetEvents(ctx context.Context, filter models.EGOTEventFilter, page pagination.Params) (pagination.Page[models.EGOTEvent], error) {
+	events, total, err := s.eventRepo.Find(ctx, filter, page)
+	if err != nil {
+		return pagination.Page[models.EGOTEvent]{}, err
+	}
+
+	return pagination.NewPage(events, total, page, func(e models.EGOTEvent) pagination.Cursor {
+		return pagination.Cursor{Key: e.CreatedAt.Time.Format(time.RFC3339Nano), ID: uuidString(e.ID)}
+	}), nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"egot-tracker/internal/feed"
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/repository"
+)
+
+// feedSize is the number of entries in the site-wide feeds
+const feedSize = 50
+
+// feedTagDate is the date in feed tag URIs. It must never change, or every
+// entry would look new to feed readers.
+const feedTagDate = "2026"
+
+type FeedService struct {
+	awardRepo     *repository.AwardRepository
+	celebrityRepo *repository.CelebrityRepository
+	eventRepo     *repository.EventRepository
+	siteURL       string
+}
+
+func NewFeedService(awardRepo *repository.AwardRepository, celebrityRepo *repository.CelebrityRepository, eventRepo *repository.EventRepository, siteURL string) *FeedService {
+	return &FeedService{
+		awardRepo:     awardRepo,
+		celebrityRepo: celebrityRepo,
+		eventRepo:     eventRepo,
+		siteURL:       siteURL,
+	}
+}
+
+// RecentAwards returns a feed of the most recently added or changed awards
+func (s *FeedService) RecentAwards(ctx context.Context) (*feed.Feed, error) {
+	awards, err := s.awardRepo.FindRecentlyUpdated(ctx, feedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feed.Entry, len(awards))
+	for i, a := range awards {
+		entries[i] = s.awardEntry(a.Celebrity, a.Award)
+	}
+
+	return &feed.Feed{
+		ID:       s.tagURI("awards"),
+		Title:    "EGOT Tracker: New Awards",
+		Subtitle: "Emmy, Grammy, Oscar and Tony wins and nominations as they are added",
+		Link:     s.siteURL,
+		Updated:  feed.LatestUpdate(time.Time{}, entries),
+		Entries:  entries,
+	}, nil
+}
+
+// CompletedEGOTs returns a feed of celebrities completing the EGOT, newest
+// first. EGOTs completed before we tracked the celebrity are left out: they
+// are dated when imported, not when won, so they would pose as news.
+func (s *FeedService) CompletedEGOTs(ctx context.Context) (*feed.Feed, error) {
+	filter := models.EGOTEventFilter{
+		Types:          []models.EGOTEventType{models.EventCompletedEGOT},
+		ExcludeInitial: true,
+	}
+	events, _, err := s.eventRepo.Find(ctx, filter, pagination.Params{Limit: feedSize})
+	if err != nil {
+		return nil, err
+	}
+	if len(events) > feedSize {
+		events = events[:feedSize]
+	}
+
+	entries := make([]feed.Entry, len(events))
+	for i, e := range events {
+		entries[i] = feed.Entry{
+			ID:    s.tagURI("event/" + uuidString(e.ID)),
+			Title: fmt.Sprintf("%s completed the EGOT", e.Celebrity.Name),
+			Link:  s.celebrityURL(e.Celebrity),
+			Summary: fmt.Sprintf("Completed with the %d %s for %s",
+				e.Award.Year, e.Award.Type, describeWork(e.Award.Category, e.Award.Work)),
+			Published: e.CreatedAt.Time,
+			Updated:   e.CreatedAt.Time,
+		}
+	}
+
+	return &feed.Feed{
+		ID:       s.tagURI("egot"),
+		Title:    "EGOT Tracker: New EGOT Winners",
+		Subtitle: "Celebrities who have won an Emmy, Grammy, Oscar and Tony",
+		Link:     s.siteURL,
+		Updated:  feed.LatestUpdate(time.Time{}, entries),
+		Entries:  entries,
+	}, nil
+}
+
+// CelebrityFeed returns a feed of one celebrity's awards, most recently
+// changed first
+func (s *FeedService) CelebrityFeed(ctx context.Context, slug string) (*feed.Feed, error) {
+	celebrity, err := s.celebrityRepo.FindBySlug(ctx, slug)
+	if errors.Is(err, repository.ErrCelebrityNotFound) {
+		return nil, ErrCelebrityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	awards, err := s.awardRepo.FindByCelebrityID(ctx, celebrity.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]feed.Entry, len(awards))
+	for i, a := range awards {
+		entries[i] = s.awardEntry(*celebrity, a)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Updated.After(entries[j].Updated)
+	})
+
+	return &feed.Feed{
+		ID:       s.tagURI("celebrity/" + uuidString(celebrity.ID)),
+		Title:    fmt.Sprintf("EGOT Tracker: %s", celebrity.Name),
+		Subtitle: fmt.Sprintf("Emmy, Grammy, Oscar and Tony wins and nominations for %s", celebrity.Name),
+		Link:     s.celebrityURL(*celebrity),
+		Updated:  feed.LatestUpdate(celebrity.LastUpdated.Time, entries),
+		Entries:  entries,
+	}, nil
+}
+
+// awardEntry describes an award as a feed entry. Its ID stays the same when
+// a nomination becomes a win, so readers show it as updated.
+func (s *FeedService) awardEntry(celebrity models.Celebrity, a models.Award) feed.Entry {
+	var title string
+	switch {
+	case a.IsWinner && !a.IsUpcoming:
+		title = fmt.Sprintf("%s won the %d %s for %s", celebrity.Name, a.Year, a.Type, a.Category)
+	case a.IsUpcoming:
+		title = fmt.Sprintf("%s is nominated for the %d %s for %s", celebrity.Name, a.Year, a.Type, a.Category)
+	default:
+		title = fmt.Sprintf("%s was nominated for the %d %s for %s", celebrity.Name, a.Year, a.Type, a.Category)
+	}
+
+	var summary string
+	if a.Work != "" {
+		summary = "For " + a.Work
+	}
+
+	return feed.Entry{
+		ID:        s.tagURI("award/" + uuidString(a.ID)),
+		Title:     title,
+		Link:      s.celebrityURL(celebrity),
+		Summary:   summary,
+		Published: a.CreatedAt.Time,
+		Updated:   a.UpdatedAt.Time,
+	}
+}
+
+// tagURI returns a permanent ID for a feed or entry (RFC 4151), so IDs do
+// not change if slugs or URLs do
+func (s *FeedService) tagURI(specific string) string {
+	host := "localhost"
+	if u, err := url.Parse(s.siteURL); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	return fmt.Sprintf("tag:%s,%s:%s", host, feedTagDate, specific)
+}
+
+func (s *FeedService) celebrityURL(celebrity models.Celebrity) string {
+	return s.siteURL + "/celebrity/" + url.PathEscape(celebrity.Slug)
+}
+
+// describeWork names an award's category and, if known, the work it was for
+func describeWork(category, work string) string {
+	if work == "" {
+		return category
+	}
+	return fmt.Sprintf("%s (%s)", category, work)
+}
@@ -3,14 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"strconv"
 
 	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
 	"egot-tracker/internal/repository"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-var ErrCeremonyNotFound = errors.New("ceremony not found")
+var ErrCeremonyNotFound = newError(KindNotFound, "ceremony_not_found", "ceremony not found")
 
 type OscarService struct {
 	oscarRepo     *repository.OscarRepository
@@ -33,14 +35,24 @@ func (s *OscarService) GetCeremony(ctx context.Context, year int) (*models.Oscar
 	return ceremony, err
 }
 
-// GetAllYears returns all tracked Oscar years
-func (s *OscarService) GetAllYears(ctx context.Context) ([]int, error) {
-	return s.oscarRepo.GetAllCeremonyYears(ctx)
+// GetAllYears returns a page of tracked Oscar years
+func (s *OscarService) GetAllYears(ctx context.Context, page pagination.Params) (pagination.Page[int], error) {
+	years, total, err := s.oscarRepo.GetAllCeremonyYears(ctx, page)
+	if err != nil {
+		return pagination.Page[int]{}, err
+	}
+	return pagination.NewPage(years, total, page, func(year int) pagination.Cursor {
+		return pagination.Cursor{Key: strconv.Itoa(year)}
+	}), nil
 }
 
 // SetWinner marks a nominee as the winner for their category
 func (s *OscarService) SetWinner(ctx context.Context, nomineeID pgtype.UUID) error {
-	return s.oscarRepo.SetNomineeAsWinner(ctx, nomineeID)
+	err := s.oscarRepo.SetNomineeAsWinner(ctx, nomineeID)
+	if errors.Is(err, repository.ErrNomineeNotFound) {
+		return ErrNomineeNotFound
+	}
+	return err
 }
 
 // CreateCeremony creates a new Oscar ceremony
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"egot-tracker/internal/metrics"
+	"egot-tracker/internal/models"
+)
+
+const (
+	refreshInterval = time.Hour
+	// refreshBatch caps the celebrities scraped per refresh, to stay polite
+	// to Wikidata
+	refreshBatch = 25
+	// Watched celebrities are refreshed daily, so watchers hear of new
+	// awards promptly; the rest are refreshed monthly
+	watchedMaxAge = 24 * time.Hour
+	staleMaxAge   = 30 * 24 * time.Hour
+	// refreshLockID is the advisory lock held while a batch is refreshed, so
+	// that one API replica refreshes at a time and a celebrity's new awards
+	// are not reported by two
+	refreshLockID = 7_105_237_681
+)
+
+// refreshes counts celebrity refreshes by whether they succeeded
+var refreshes = metrics.NewCounter(
+	"egot_celebrity_refresh_total",
+	"Scheduled celebrity refreshes by result (ok or failed).",
+	"result",
+)
+
+// RefreshCelebrity scrapes a stored celebrity again and saves what changed,
+// recording milestones and queuing webhooks as a first scrape does. The
+// celebrity is marked as updated even if the scrape fails, so one that
+// cannot be scraped is not retried on every refresh.
+func (s *CelebrityService) RefreshCelebrity(ctx context.Context, celebrity models.Celebrity) error {
+	scraped, err := s.scraper.FetchCelebrity(ctx, celebrity.Name)
+	if err == nil && !strings.EqualFold(scraped.Celebrity.Name, celebrity.Name) {
+		err = fmt.Errorf("Wikidata resolved %q to %q", celebrity.Name, scraped.Celebrity.Name)
+	}
+	if err != nil {
+		if touchErr := s.celebrityRepo.Touch(ctx, celebrity.ID); touchErr != nil {
+			return touchErr
+		}
+		return err
+	}
+
+	_, _, err = s.saveScrape(ctx, scraped, &celebrity)
+	return err
+}
+
+// Refresher periodically scrapes watched and stale celebrities again, so
+// that awards won or nominated after a celebrity was first searched for are
+// picked up
+type Refresher struct {
+	celebrities *CelebrityService
+}
+
+func NewRefresher(celebrities *CelebrityService) *Refresher {
+	return &Refresher{celebrities: celebrities}
+}
+
+// Run refreshes the celebrities that are due every refreshInterval, starting
+// one interval after it is called so that restarts do not scrape, until ctx
+// is cancelled
+func (r *Refresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		refreshed, err := r.celebrities.uow.WithTryLock(ctx, refreshLockID, func() error {
+			return r.refreshDue(ctx)
+		})
+		switch {
+		case err != nil && ctx.Err() == nil:
+			slog.Error("celebrity refresh failed", "error", err)
+		case err == nil && !refreshed:
+			slog.Debug("celebrity refresh skipped, another process is refreshing")
+		}
+	}
+}
+
+// refreshDue refreshes one batch of the celebrities that are due. A
+// celebrity that fails to refresh is logged and skipped.
+func (r *Refresher) refreshDue(ctx context.Context) error {
+	now := time.Now()
+	due, err := r.celebrities.celebrityRepo.FindDueForRefresh(ctx, now.Add(-watchedMaxAge), now.Add(-staleMaxAge), refreshBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, celebrity := range due {
+		if err := r.celebrities.RefreshCelebrity(ctx, celebrity); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			refreshes.Inc("failed")
+			slog.Warn("failed to refresh celebrity", "celebrity", celebrity.Name, "error", err)
+			continue
+		}
+		refreshes.Inc("ok")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/repository"
+)
+
+var ErrUnknownMetric = newError(KindNotFound, "unknown_metric", "unknown leaderboard metric")
+
+// statsCacheTTL is how long aggregate stats are served from memory before
+// being recomputed
+const statsCacheTTL = 5 * time.Minute
+
+type StatsService struct {
+	statsRepo     *repository.StatsRepository
+	celebrityRepo *repository.CelebrityRepository
+	awardRepo     *repository.AwardRepository
+
+	mu          sync.Mutex
+	cached      *models.Stats
+	cachedUntil time.Time
+}
+
+func NewStatsService(
+	statsRepo *repository.StatsRepository,
+	celebrityRepo *repository.CelebrityRepository,
+	awardRepo *repository.AwardRepository,
+) *StatsService {
+	return &StatsService{
+		statsRepo:     statsRepo,
+		celebrityRepo: celebrityRepo,
+		awardRepo:     awardRepo,
+	}
+}
+
+// GetStats returns aggregate counts over the tracked celebrities, computing
+// them at most once per statsCacheTTL
+func (s *StatsService) GetStats(ctx context.Context) (*models.Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && time.Now().Before(s.cachedUntil) {
+		return s.cached, nil
+	}
+
+	stats, err := s.statsRepo.GetStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cached = stats
+	s.cachedUntil = time.Now().Add(statsCacheTTL)
+	return stats, nil
+}
+
+// GetLeaderboard returns a page of the celebrities matching the filter,
+// ranked by the metric. The filter's sort is ignored.
+func (s *StatsService) GetLeaderboard(ctx context.Context, metric models.LeaderboardMetric, filter models.CelebrityFilter, page pagination.Params) (pagination.Page[models.LeaderboardEntry], error) {
+	entries, total, err := s.celebrityRepo.Leaderboard(ctx, metric, filter, page)
+	if errors.Is(err, repository.ErrUnknownMetric) {
+		return pagination.Page[models.LeaderboardEntry]{}, ErrUnknownMetric
+	}
+	if err != nil {
+		return pagination.Page[models.LeaderboardEntry]{}, err
+	}
+
+	return pagination.NewPage(entries, total, page, func(e models.LeaderboardEntry) pagination.Cursor {
+		return pagination.Cursor{Key: strconv.Itoa(e.Value), ID: uuidString(e.ID)}
+	}), nil
+}
+
+// EGOTTimelineStats lists every EGOT winner's timeline in two orders
+type EGOTTimelineStats struct {
+	ByCompletion []models.CelebrityEGOTTimeline `json:"by_completion"`
+	BySpan       []models.CelebrityEGOTTimeline `json:"by_span"`
+}
+
+// GetEGOTTimelines returns the timeline of every EGOT winner, ordered by
+// when they completed the EGOT and by how quickly they did it. Winners whose
+// completion year is unknown are listed last in both orders.
+func (s *StatsService) GetEGOTTimelines(ctx context.Context) (*EGOTTimelineStats, error) {
+	celebrities, err := s.celebrityRepo.FindEGOTWinners(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wins, err := s.awardRepo.FindEGOTWinnerWins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	winsByCelebrity := make(map[[16]byte][]models.Award)
+	for _, a := range wins {
+		winsByCelebrity[a.CelebrityID.Bytes] = append(winsByCelebrity[a.CelebrityID.Bytes], a)
+	}
+
+	timelines := make([]models.CelebrityEGOTTimeline, len(celebrities))
+	for i, c := range celebrities {
+		timelines[i] = models.CelebrityEGOTTimeline{
+			Celebrity: c,
+			Timeline:  models.BuildEGOTTimeline(winsByCelebrity[c.ID.Bytes]),
+		}
+	}
+
+	byCompletion := append([]models.CelebrityEGOTTimeline(nil), timelines...)
+	sort.SliceStable(byCompletion, func(i, j int) bool {
+		a, b := byCompletion[i].Timeline, byCompletion[j].Timeline
+		if lessKnown(a.CompletedYear, b.CompletedYear) {
+			return true
+		}
+		if !equalKnown(a.CompletedYear, b.CompletedYear) {
+			return false
+		}
+		if a.CompletedDate != nil && b.CompletedDate != nil {
+			return a.CompletedDate.Before(*b.CompletedDate)
+		}
+		return a.CompletedDate != nil && b.CompletedDate == nil
+	})
+
+	bySpan := append([]models.CelebrityEGOTTimeline(nil), timelines...)
+	sort.SliceStable(bySpan, func(i, j int) bool {
+		a, b := bySpan[i].Timeline, bySpan[j].Timeline
+		if lessKnown(a.SpanYears, b.SpanYears) {
+			return true
+		}
+		if !equalKnown(a.SpanYears, b.SpanYears) {
+			return false
+		}
+		return lessKnown(a.CompletedYear, b.CompletedYear)
+	})
+
+	return &EGOTTimelineStats{ByCompletion: byCompletion, BySpan: bySpan}, nil
+}
+
+// lessKnown orders optional ints ascending with unknown values last
+func lessKnown(a, b *int) bool {
+	if a == nil || b == nil {
+		return a != nil
+	}
+	return *a < *b
+}
+
+// equalKnown reports whether two optional ints are both unknown or equal
+func equalKnown(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
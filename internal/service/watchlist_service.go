@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/pagination"
+	"egot-tracker/internal/repository"
+	"egot-tracker/internal/webhook"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrWatchlistNotFound  = newError(KindNotFound, "watchlist_not_found", "watchlist not found")
+	ErrInvalidWatchlist   = invalidField("invalid_watchlist_name", "name", "watchlist name is required")
+	ErrInvalidWebhookURL  = invalidField("invalid_webhook_url", "webhook_url", "webhook_url must be an absolute http or https URL")
+	ErrInternalWebhookURL = invalidField("internal_webhook_url", "webhook_url", "webhook_url must not point at a loopback, link-local or private address")
+)
+
+// Prefixes of the API keys and calendar tokens issued by this service, so
+// leaked ones are easy to recognise
+const (
+	apiKeyPrefix        = "egot_"
+	calendarTokenPrefix = "egotcal_"
+)
+
+type WatchlistService struct {
+	apiKeyRepo    *repository.APIKeyRepository
+	watchlistRepo *repository.WatchlistRepository
+	webhookRepo   *repository.WebhookRepository
+	celebrityRepo *repository.CelebrityRepository
+}
+
+func NewWatchlistService(
+	apiKeyRepo *repository.APIKeyRepository,
+	watchlistRepo *repository.WatchlistRepository,
+	webhookRepo *repository.WebhookRepository,
+	celebrityRepo *repository.CelebrityRepository,
+) *WatchlistService {
+	return &WatchlistService{
+		apiKeyRepo:    apiKeyRepo,
+		watchlistRepo: watchlistRepo,
+		webhookRepo:   webhookRepo,
+		celebrityRepo: celebrityRepo,
+	}
+}
+
+// hashToken returns the hex SHA-256 hash stored for an API key or calendar
+// token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns a random hex string carrying n bytes of entropy
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// CreateAPIKey issues a new API key, which may also curate data if isAdmin.
+// The key is returned only here; just its hash is stored.
+func (s *WatchlistService) CreateAPIKey(ctx context.Context, name string, isAdmin bool) (*models.APIKey, string, error) {
+	token, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	key := apiKeyPrefix + token
+
+	created, err := s.apiKeyRepo.Create(ctx, strings.TrimSpace(name), hashToken(key), isAdmin)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, key, nil
+}
+
+// RevokeAPIKey revokes every active API key with the given name and returns
+// how many were revoked
+func (s *WatchlistService) RevokeAPIKey(ctx context.Context, name string) (int64, error) {
+	return s.apiKeyRepo.Revoke(ctx, strings.TrimSpace(name))
+}
+
+// Authenticate returns the active API key matching key
+func (s *WatchlistService) Authenticate(ctx context.Context, key string) (*models.APIKey, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.apiKeyRepo.FindActiveByHash(ctx, hashToken(key))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrInvalidAPIKey
+	}
+	return apiKey, err
+}
+
+// validateWebhookURL checks that a webhook URL is absolute http(s) and does
+// not point at an internal host. Names are checked again when dialled, as
+// they may resolve to internal addresses.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+	if !webhook.IsPublicHost(u.Hostname()) {
+		return ErrInternalWebhookURL
+	}
+	return nil
+}
+
+// CreateWatchlist creates a watchlist owned by an API key. The returned
+// secret signs the watchlist's webhook payloads and is shown only here.
+func (s *WatchlistService) CreateWatchlist(ctx context.Context, apiKeyID pgtype.UUID, name, webhookURL string) (*models.Watchlist, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrInvalidWatchlist
+	}
+
+	watchlist := &models.Watchlist{APIKeyID: apiKeyID, Name: name}
+	if webhookURL = strings.TrimSpace(webhookURL); webhookURL != "" {
+		if err := validateWebhookURL(webhookURL); err != nil {
+			return nil, "", err
+		}
+		watchlist.WebhookURL = pgtype.Text{String: webhookURL, Valid: true}
+	}
+
+	secret, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	watchlist.WebhookSecret = secret
+
+	created, err := s.watchlistRepo.Create(ctx, watchlist)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, secret, nil
+}
+
+// GetWatchlists returns the watchlists owned by an API key
+func (s *WatchlistService) GetWatchlists(ctx context.Context, apiKeyID pgtype.UUID) ([]models.Watchlist, error) {
+	return s.watchlistRepo.FindByAPIKey(ctx, apiKeyID)
+}
+
+// GetWatchlist returns a watchlist owned by an API key with its celebrities
+func (s *WatchlistService) GetWatchlist(ctx context.Context, apiKeyID, id pgtype.UUID) (*models.WatchlistWithCelebrities, error) {
+	watchlist, err := s.findWatchlist(ctx, apiKeyID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	celebrities, err := s.watchlistRepo.FindCelebrities(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if celebrities == nil {
+		celebrities = []models.Celebrity{}
+	}
+
+	return &models.WatchlistWithCelebrities{Watchlist: *watchlist, Celebrities: celebrities}, nil
+}
+
+// DeleteWatchlist deletes a watchlist owned by an API key, with its deliveries
+func (s *WatchlistService) DeleteWatchlist(ctx context.Context, apiKeyID, id pgtype.UUID) error {
+	err := s.watchlistRepo.Delete(ctx, apiKeyID, id)
+	if errors.Is(err, repository.ErrWatchlistNotFound) {
+		return ErrWatchlistNotFound
+	}
+	return err
+}
+
+// AddCelebrity puts a celebrity on a watchlist owned by an API key
+func (s *WatchlistService) AddCelebrity(ctx context.Context, apiKeyID, id, celebrityID pgtype.UUID) error {
+	if _, err := s.findWatchlist(ctx, apiKeyID, id); err != nil {
+		return err
+	}
+	if _, err := s.celebrityRepo.FindByID(ctx, celebrityID); err != nil {
+		if errors.Is(err, repository.ErrCelebrityNotFound) {
+			return ErrCelebrityNotFound
+		}
+		return err
+	}
+	return s.watchlistRepo.AddCelebrity(ctx, id, celebrityID)
+}
+
+// RemoveCelebrity takes a celebrity off a watchlist owned by an API key
+func (s *WatchlistService) RemoveCelebrity(ctx context.Context, apiKeyID, id, celebrityID pgtype.UUID) error {
+	if _, err := s.findWatchlist(ctx, apiKeyID, id); err != nil {
+		return err
+	}
+	return s.watchlistRepo.RemoveCelebrity(ctx, id, celebrityID)
+}
+
+// IssueCalendarToken issues a new calendar token for a watchlist owned by an
+// API key, replacing any previous one. The token only reads the watchlist's
+// calendar feed, so unlike the API key it is safe to put in a calendar URL.
+// It is returned only here; just its hash is stored.
+func (s *WatchlistService) IssueCalendarToken(ctx context.Context, apiKeyID, id pgtype.UUID) (string, error) {
+	random, err := randomToken(32)
+	if err != nil {
+		return "", err
+	}
+	token := calendarTokenPrefix + random
+
+	err = s.watchlistRepo.SetCalendarTokenHash(ctx, apiKeyID, id, hashToken(token))
+	if errors.Is(err, repository.ErrWatchlistNotFound) {
+		return "", ErrWatchlistNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// GetDeliveries returns a page of a watchlist's webhook deliveries, newest
+// first, with the log of attempts to send each
+func (s *WatchlistService) GetDeliveries(ctx context.Context, apiKeyID, id pgtype.UUID, page pagination.Params) (pagination.Page[models.WebhookDeliveryWithAttempts], error) {
+	if _, err := s.findWatchlist(ctx, apiKeyID, id); err != nil {
+		return pagination.Page[models.WebhookDeliveryWithAttempts]{}, err
+	}
+
+	deliveries, total, err := s.webhookRepo.FindByWatchlist(ctx, id, page)
+	if err != nil {
+		return pagination.Page[models.WebhookDeliveryWithAttempts]{}, err
+	}
+
+	return pagination.NewPage(deliveries, total, page, func(d models.WebhookDeliveryWithAttempts) pagination.Cursor {
+		return pagination.Cursor{Key: d.CreatedAt.Time.Format(time.RFC3339Nano), ID: uuidString(d.ID)}
+	}), nil
+}
+
+func (s *WatchlistService) findWatchlist(ctx context.Context, apiKeyID, id pgtype.UUID) (*models.Watchlist, error) {
+	watchlist, err := s.watchlistRepo.FindByID(ctx, apiKeyID, id)
+	if errors.Is(err, repository.ErrWatchlistNotFound) {
+		return nil, ErrWatchlistNotFound
+	}
+	return watchlist, err
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// enqueueAwardWebhooks adds a delivery to the outbox for every change to a
+// celebrity's awards and every watchlist with a webhook that includes them.
+// It runs in the transaction that wrote the awards, so deliveries are queued
+// if and only if the change is committed.
+func enqueueAwardWebhooks(ctx context.Context, repos *repository.Repositories, celebrity models.Celebrity, changes models.AwardChanges) error {
+	if changes.IsEmpty() {
+		return nil
+	}
+
+	watchlists, err := repos.Watchlists.FindWatching(ctx, celebrity.ID)
+	if err != nil || len(watchlists) == 0 {
+		return err
+	}
+
+	now := pgtype.Timestamptz{Time: time.Now().UTC(), Valid: true}
+	var events []models.WebhookEvent
+	for i := range changes.NewWins {
+		events = append(events, models.WebhookEvent{
+			Type:      models.EventAwardWon,
+			Celebrity: celebrity,
+			Award:     &changes.NewWins[i],
+			CreatedAt: now,
+		})
+	}
+	for i := range changes.NewNominations {
+		events = append(events, models.WebhookEvent{
+			Type:      models.EventNominationAdded,
+			Celebrity: celebrity,
+			Award:     &changes.NewNominations[i],
+			CreatedAt: now,
+		})
+	}
+	if changes.ProgressChanged() {
+		progress := changes.Progress
+		events = append(events, models.WebhookEvent{
+			Type:      models.EventEGOTProgressChanged,
+			Celebrity: celebrity,
+			Progress:  &progress,
+			CreatedAt: now,
+		})
+	}
+
+	deliveries := make([]models.WebhookDelivery, 0, len(events)*len(watchlists))
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		key := dedupeKey(event)
+		for _, w := range watchlists {
+			deliveries = append(deliveries, models.WebhookDelivery{
+				WatchlistID: w.ID,
+				EventType:   event.Type,
+				Payload:     payload,
+				DedupeKey:   key,
+			})
+		}
+	}
+
+	return repos.Webhooks.Enqueue(ctx, deliveries)
+}
+
+// dedupeKey identifies the change an event reports: an award won or
+// nominated, or a step in a celebrity's EGOT progress. Two writers that see
+// the same change, such as refreshes racing on two API replicas, queue it
+// once per watchlist.
+func dedupeKey(event models.WebhookEvent) string {
+	if event.Award != nil {
+		return fmt.Sprintf("%s:%s", event.Type, uuidString(event.Award.ID))
+	}
+	return fmt.Sprintf("%s:%s:%d-%d", event.Type, uuidString(event.Celebrity.ID), event.Progress.Previous, event.Progress.Current)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrWorkNotFound = newError(KindNotFound, "work_not_found", "work not found")
+
+// wikidataIDPattern matches a Wikidata item ID such as "Q1140578"
+var wikidataIDPattern = regexp.MustCompile(`^Q[1-9][0-9]*$`)
+
+type WorkService struct {
+	workRepo *repository.WorkRepository
+}
+
+func NewWorkService(workRepo *repository.WorkRepository) *WorkService {
+	return &WorkService{workRepo: workRepo}
+}
+
+// IsWikidataID reports whether id is a Wikidata item ID rather than a work's UUID
+func IsWikidataID(id string) bool {
+	return wikidataIDPattern.MatchString(id)
+}
+
+// GetWork returns a work with every tracked celebrity's awards for it. The
+// work is looked up by its Wikidata ID if one is given, otherwise by UUID.
+func (s *WorkService) GetWork(ctx context.Context, id pgtype.UUID, wikidataID string) (*models.WorkWithCredits, error) {
+	var work *models.Work
+	var err error
+	if wikidataID != "" {
+		work, err = s.workRepo.FindByWikidataID(ctx, wikidataID)
+	} else {
+		work, err = s.workRepo.FindByID(ctx, id)
+	}
+	if errors.Is(err, repository.ErrWorkNotFound) {
+		return nil, ErrWorkNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	credits, err := s.workRepo.FindCredits(ctx, work.ID)
+	if err != nil {
+		return nil, err
+	}
+	if credits == nil {
+		credits = []models.WorkCredit{}
+	}
+
+	return &models.WorkWithCredits{Work: *work, Credits: credits}, nil
+}
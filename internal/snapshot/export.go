@@ -0,0 +1,90 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"egot-tracker/internal/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Export reads every snapshot table in one read-only transaction, so the
+// snapshot is consistent even while the API is writing. Rows are ordered by
+// ID so exports of the same data are identical.
+func Export(ctx context.Context, pool *pgxpool.Pool) (*Snapshot, error) {
+	if err := database.CheckSchema(ctx, pool); err != nil {
+		return nil, err
+	}
+	migrations, err := database.LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{
+		Header: Header{
+			Format:        FormatName,
+			Version:       FormatVersion,
+			SchemaVersion: migrations[len(migrations)-1].Version,
+			ExportedAt:    time.Now().UTC(),
+			Counts:        make(map[string]int, len(tables)),
+		},
+		Tables: make(map[string][]Row, len(tables)),
+	}
+
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err = pgx.BeginTxFunc(ctx, pool, txOptions, func(tx pgx.Tx) error {
+		for _, t := range tables {
+			rows, err := exportTable(ctx, tx, t)
+			if err != nil {
+				return fmt.Errorf("%s: %w", t.name, err)
+			}
+			s.Tables[t.name] = rows
+			s.Header.Counts[t.name] = len(rows)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// exportTable has PostgreSQL encode each row as JSON, so values keep their
+// types (numbers, booleans, ISO dates and timestamps)
+func exportTable(ctx context.Context, tx pgx.Tx, t table) ([]Row, error) {
+	query := fmt.Sprintf(
+		`SELECT row_to_json(t)::text FROM (SELECT %s FROM %s ORDER BY id) t`,
+		strings.Join(t.columnNames(), ", "), t.name,
+	)
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []Row
+	for rows.Next() {
+		var encoded []byte
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(encoded))
+		dec.UseNumber()
+		var row Row
+		if err := dec.Decode(&row); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	return result, rows.Err()
+}
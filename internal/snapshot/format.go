@@ -0,0 +1,277 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// FormatName identifies snapshot files
+const FormatName = "egot-tracker-snapshot"
+
+// FormatVersion is the version of the snapshot format written by Export.
+// It changes only when existing readers could no longer understand a
+// snapshot, not with every schema migration.
+const FormatVersion = 1
+
+// csvNull stands for NULL in CSV files, as in PostgreSQL's COPY
+const csvNull = `\N`
+
+// Header describes a snapshot
+type Header struct {
+	Format        string         `json:"format"`
+	Version       int            `json:"version"`
+	SchemaVersion int            `json:"schema_version"` // migration the source database was at
+	ExportedAt    time.Time      `json:"exported_at"`
+	Counts        map[string]int `json:"counts"` // rows per table
+}
+
+// Row is one table row, with values as decoded from JSON: string,
+// json.Number, bool or nil
+type Row map[string]any
+
+// Snapshot is a header with the rows of each table
+type Snapshot struct {
+	Header Header
+	Tables map[string][]Row
+}
+
+// jsonlRecord is a line of a JSON-lines snapshot after the header
+type jsonlRecord struct {
+	Table string `json:"table"`
+	Row   Row    `json:"row"`
+}
+
+// WriteJSONL writes a snapshot as JSON lines: the header, then one
+// {"table": ..., "row": {...}} object per row, in table order
+func WriteJSONL(w io.Writer, s *Snapshot) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	if err := enc.Encode(s.Header); err != nil {
+		return err
+	}
+	for _, t := range tables {
+		for _, row := range s.Tables[t.name] {
+			if err := enc.Encode(jsonlRecord{Table: t.name, Row: row}); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadJSONL reads a snapshot written by WriteJSONL
+func ReadJSONL(r io.Reader) (*Snapshot, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	s := &Snapshot{Tables: make(map[string][]Row)}
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.UseNumber()
+		if line == 1 {
+			if err := dec.Decode(&s.Header); err != nil {
+				return nil, fmt.Errorf("line 1: invalid header: %w", err)
+			}
+			if err := checkHeader(s.Header); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var record jsonlRecord
+		if err := dec.Decode(&record); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if _, ok := findTable(record.Table); !ok {
+			return nil, fmt.Errorf("line %d: unknown table %q", line, record.Table)
+		}
+		s.Tables[record.Table] = append(s.Tables[record.Table], record.Row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if line == 0 {
+		return nil, errors.New("snapshot is empty")
+	}
+
+	return s, nil
+}
+
+// WriteCSV writes a snapshot to a directory: the header as manifest.json and
+// one CSV file per table with a header row. NULL is written as \N.
+func WriteCSV(dir string, s *Snapshot) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	manifest, err := json.MarshalIndent(s.Header, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), append(manifest, '\n'), 0o644); err != nil {
+		return err
+	}
+
+	for _, t := range tables {
+		if err := writeCSVTable(filepath.Join(dir, t.name+".csv"), t, s.Tables[t.name]); err != nil {
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+func writeCSVTable(path string, t table, rows []Row) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write(t.columnNames())
+	record := make([]string, len(t.columns))
+	for _, row := range rows {
+		for i, c := range t.columns {
+			record[i] = csvValue(row[c.name])
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return csvNull
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ReadCSV reads a snapshot written by WriteCSV. Values are converted to the
+// types JSON would give them, so both formats validate the same way.
+func ReadCSV(dir string) (*Snapshot, error) {
+	manifest, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{Tables: make(map[string][]Row)}
+	if err := json.Unmarshal(manifest, &s.Header); err != nil {
+		return nil, fmt.Errorf("manifest.json: %w", err)
+	}
+	if err := checkHeader(s.Header); err != nil {
+		return nil, err
+	}
+
+	for _, t := range tables {
+		rows, err := readCSVTable(filepath.Join(dir, t.name+".csv"), t)
+		if err != nil {
+			return nil, fmt.Errorf("%s.csv: %w", t.name, err)
+		}
+		s.Tables[t.name] = rows
+	}
+	return s, nil
+}
+
+func readCSVTable(path string, t table) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header row: %w", err)
+	}
+
+	columns := make([]*column, len(header))
+	for i, name := range header {
+		for j := range t.columns {
+			if t.columns[j].name == name {
+				columns[i] = &t.columns[j]
+			}
+		}
+		if columns[i] == nil {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+
+	var rows []Row
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(Row, len(record))
+		for i, raw := range record {
+			value, err := parseCSVValue(*columns[i], raw)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			row[columns[i].name] = value
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func parseCSVValue(c column, raw string) (any, error) {
+	if raw == csvNull {
+		return nil, nil
+	}
+	switch c.kind {
+	case kindBool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", c.name, raw)
+		}
+		return b, nil
+	case kindInt:
+		return json.Number(raw), nil
+	default:
+		return raw, nil
+	}
+}
+
+// checkHeader rejects files that are not snapshots or are too new to read
+func checkHeader(h Header) error {
+	if h.Format != FormatName {
+		return fmt.Errorf("not a snapshot (format %q)", h.Format)
+	}
+	if h.Version < 1 || h.Version > FormatVersion {
+		return fmt.Errorf("unsupported snapshot version %d (this build reads up to %d)", h.Version, FormatVersion)
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"egot-tracker/internal/database"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxProblems caps the problems reported by a failed validation
+const maxProblems = 50
+
+// ImportOptions controls how a snapshot is restored
+type ImportOptions struct {
+	// Merge upserts rows by ID into a database that already has data.
+	// Without it, the snapshot tables must be empty.
+	Merge bool
+	// DryRun validates and writes the snapshot, then rolls back
+	DryRun bool
+	// TrackAwards, if set, is called in the import transaction to write the
+	// rows, with the IDs of the celebrities the snapshot has awards for, so
+	// that changes to their awards can be recorded as a scrape records them
+	TrackAwards func(ctx context.Context, tx pgx.Tx, celebrityIDs []string, write func() error) error
+}
+
+// ValidationError lists what is wrong with a snapshot
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid snapshot:\n  %s", strings.Join(e.Problems, "\n  "))
+}
+
+var (
+	ErrDatabaseNotEmpty = errors.New("database already has data; use merge to upsert into it")
+	errDryRun           = errors.New("dry run")
+)
+
+// Import validates a snapshot and writes it in one transaction, returning
+// the number of rows written per table. Nothing is written if validation
+// fails.
+func Import(ctx context.Context, pool *pgxpool.Pool, s *Snapshot, opts ImportOptions) (map[string]int, error) {
+	if err := database.CheckSchema(ctx, pool); err != nil {
+		return nil, err
+	}
+
+	problems, references := validate(s)
+
+	written := make(map[string]int, len(tables))
+	err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		// References outside the snapshot must already be in the database
+		missing, err := missingReferences(ctx, tx, references, opts.Merge)
+		if err != nil {
+			return err
+		}
+		problems = append(problems, missing...)
+		if len(problems) > 0 {
+			return &ValidationError{Problems: capProblems(problems)}
+		}
+
+		if !opts.Merge {
+			for _, t := range tables {
+				var exists bool
+				if err := tx.QueryRow(ctx, fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", t.name)).Scan(&exists); err != nil {
+					return err
+				}
+				if exists {
+					return fmt.Errorf("%w (%s has rows)", ErrDatabaseNotEmpty, t.name)
+				}
+			}
+		}
+
+		// Rows are upserted by ID, so rows sharing a unique name or natural
+		// key with a different ID cannot be written
+		conflicts, err := uniqueConflicts(ctx, tx, s, opts.Merge)
+		if err != nil {
+			return err
+		}
+		if len(conflicts) > 0 {
+			return &ValidationError{Problems: capProblems(conflicts)}
+		}
+
+		write := func() error {
+			for _, t := range tables {
+				if err := importTable(ctx, tx, t, s.Tables[t.name], opts.Merge); err != nil {
+					return err
+				}
+				written[t.name] = len(s.Tables[t.name])
+			}
+			return nil
+		}
+		if opts.TrackAwards != nil {
+			err = opts.TrackAwards(ctx, tx, awardCelebrityIDs(s), write)
+		} else {
+			err = write()
+		}
+		if err != nil {
+			return err
+		}
+
+		// Milestone events are not in snapshots; record those reached by
+		// the imported awards
+		if _, err := repository.NewEventRepository(tx).Backfill(ctx); err != nil {
+			return err
+		}
+
+		if opts.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return written, nil
+}
+
+// validate checks every row against its table and the header counts. It
+// returns the problems found and, per table, the referenced IDs that are
+// not in the snapshot.
+func validate(s *Snapshot) ([]string, map[string]map[string]bool) {
+	var problems []string
+	ids := make(map[string]map[string]bool, len(tables))
+	for _, t := range tables {
+		rows := s.Tables[t.name]
+		if want, ok := s.Header.Counts[t.name]; ok && want != len(rows) {
+			problems = append(problems, fmt.Sprintf("%s: header says %d rows, found %d", t.name, want, len(rows)))
+		}
+
+		ids[t.name] = make(map[string]bool, len(rows))
+		for i, row := range rows {
+			for _, p := range validateRow(t, row) {
+				problems = append(problems, fmt.Sprintf("%s row %d: %s", t.name, i+1, p))
+			}
+			if id, ok := row["id"].(string); ok {
+				id = strings.ToLower(id)
+				if ids[t.name][id] {
+					problems = append(problems, fmt.Sprintf("%s row %d: duplicate id %s", t.name, i+1, id))
+				}
+				ids[t.name][id] = true
+			}
+		}
+	}
+
+	references := make(map[string]map[string]bool)
+	for _, t := range tables {
+		for _, c := range t.columns {
+			if c.references == "" {
+				continue
+			}
+			for _, row := range s.Tables[t.name] {
+				id, ok := row[c.name].(string)
+				if !ok || ids[c.references][strings.ToLower(id)] {
+					continue
+				}
+				if references[c.references] == nil {
+					references[c.references] = make(map[string]bool)
+				}
+				references[c.references][strings.ToLower(id)] = true
+			}
+		}
+	}
+
+	return problems, references
+}
+
+// awardCelebrityIDs returns the IDs of the celebrities with awards in the
+// snapshot
+func awardCelebrityIDs(s *Snapshot) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, row := range s.Tables["awards"] {
+		id, ok := row["celebrity_id"].(string)
+		if !ok || seen[strings.ToLower(id)] {
+			continue
+		}
+		seen[strings.ToLower(id)] = true
+		ids = append(ids, strings.ToLower(id))
+	}
+	return ids
+}
+
+// validateRow checks that a row has exactly the table's columns with valid
+// values
+func validateRow(t table, row Row) []string {
+	var problems []string
+	for _, c := range t.columns {
+		value, ok := row[c.name]
+		if !ok && !c.nullable {
+			problems = append(problems, fmt.Sprintf("%s is missing", c.name))
+			continue
+		}
+		if err := c.check(value); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+	for name := range row {
+		if !containsColumn(t, name) {
+			problems = append(problems, fmt.Sprintf("unknown column %s", name))
+		}
+	}
+	return problems
+}
+
+func containsColumn(t table, name string) bool {
+	for _, c := range t.columns {
+		if c.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// missingReferences reports referenced IDs that are neither in the snapshot
+// nor, when merging, in the database
+func missingReferences(ctx context.Context, tx pgx.Tx, references map[string]map[string]bool, merge bool) ([]string, error) {
+	var problems []string
+	for _, t := range tables {
+		wanted := references[t.name]
+		if len(wanted) == 0 {
+			continue
+		}
+
+		found := make(map[string]bool)
+		if merge {
+			ids := make([]string, 0, len(wanted))
+			for id := range wanted {
+				ids = append(ids, id)
+			}
+			rows, err := tx.Query(ctx, fmt.Sprintf("SELECT id::text FROM %s WHERE id = ANY($1::uuid[])", t.name), ids)
+			if err != nil {
+				return nil, err
+			}
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					rows.Close()
+					return nil, err
+				}
+				found[id] = true
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		where := "the snapshot"
+		if merge {
+			where = "the snapshot or the database"
+		}
+		for id := range wanted {
+			if !found[id] {
+				problems = append(problems, fmt.Sprintf("%s: id %s is referenced but not in %s", t.name, id, where))
+			}
+		}
+	}
+	return problems, nil
+}
+
+// uniqueConflicts reports rows that share a unique key other than the ID
+// with another row in the snapshot or, when merging, with a row in the
+// database that has a different ID. Keys are compared in SQL, so that they
+// match the database's own constraints exactly.
+func uniqueConflicts(ctx context.Context, tx pgx.Tx, s *Snapshot, merge bool) ([]string, error) {
+	var problems []string
+	for _, t := range tables {
+		rows := s.Tables[t.name]
+		if len(t.unique) == 0 || len(rows) == 0 {
+			continue
+		}
+		encoded, err := json.Marshal(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range t.unique {
+			query := fmt.Sprintf(`
+				WITH s AS (SELECT * FROM json_populate_recordset(NULL::%[1]s, $1::json))
+				SELECT a.id::text, b.id::text, false
+				FROM s a
+				INNER JOIN s b ON %[2]s = %[3]s AND a.id < b.id`,
+				t.name, fmt.Sprintf(key.expr, "a"), fmt.Sprintf(key.expr, "b"),
+			)
+			if merge {
+				query += fmt.Sprintf(`
+				UNION ALL
+				SELECT s.id::text, t.id::text, true
+				FROM s
+				INNER JOIN %[1]s t ON %[2]s = %[3]s AND t.id <> s.id`,
+					t.name, fmt.Sprintf(key.expr, "s"), fmt.Sprintf(key.expr, "t"),
+				)
+			}
+			query += fmt.Sprintf("\nLIMIT %d", maxProblems+1)
+
+			dbRows, err := tx.Query(ctx, query, string(encoded))
+			if err != nil {
+				return nil, fmt.Errorf("%s: checking %s: %w", t.name, key.name, err)
+			}
+			for dbRows.Next() {
+				var id, other string
+				var inDatabase bool
+				if err := dbRows.Scan(&id, &other, &inDatabase); err != nil {
+					dbRows.Close()
+					return nil, err
+				}
+				if inDatabase {
+					problems = append(problems, fmt.Sprintf("%s: id %s has the same %s as id %s in the database", t.name, id, key.name, other))
+				} else {
+					problems = append(problems, fmt.Sprintf("%s: ids %s and %s have the same %s", t.name, id, other, key.name))
+				}
+			}
+			dbRows.Close()
+			if err := dbRows.Err(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return problems, nil
+}
+
+// capProblems truncates a list of problems to maxProblems
+func capProblems(problems []string) []string {
+	if len(problems) > maxProblems {
+		problems = append(problems[:maxProblems], fmt.Sprintf("... and %d more", len(problems)-maxProblems))
+	}
+	return problems
+}
+
+// importTable writes a table's rows in one round trip. PostgreSQL decodes
+// each row from JSON into the table's column types.
+func importTable(ctx context.Context, tx pgx.Tx, t table, rows []Row, merge bool) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	columns := strings.Join(t.columnNames(), ", ")
+	query := fmt.Sprintf(
+		`INSERT INTO %s (%s) SELECT %s FROM json_populate_record(NULL::%s, $1::json)`,
+		t.name, columns, columns, t.name,
+	)
+	if merge {
+		updates := make([]string, 0, len(t.columns)-1)
+		for _, c := range t.columns[1:] {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", c.name, c.name))
+		}
+		query += " ON CONFLICT (id) DO UPDATE SET " + strings.Join(updates, ", ")
+	}
+
+	batch := &pgx.Batch{}
+	for _, row := range rows {
+		encoded, err := json.Marshal(row)
+		if err != nil {
+			return err
+		}
+		batch.Queue(query, string(encoded))
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+	for _, row := range rows {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("%s %v: %w", t.name, row["id"], err)
+		}
+	}
+	return results.Close()
+}
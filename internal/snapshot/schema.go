@@ -0,0 +1,209 @@
+// Package snapshot exports the dataset to a portable, versioned snapshot
+// and restores it, independent of the database schema version
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// kind is the type of a column's values in a snapshot
+type kind int
+
+const (
+	kindUUID kind = iota
+	kindText
+	kindInt
+	kindBool
+	kindDate      // YYYY-MM-DD
+	kindTimestamp // RFC 3339
+)
+
+type column struct {
+	name       string
+	kind       kind
+	nullable   bool
+	values     []string // allowed values, if restricted
+	references string   // table whose id this column holds, if any
+}
+
+// uniqueKey is a unique constraint of a table besides its primary key. expr
+// is the constrained SQL expression, with %[1]s standing for a row alias.
+type uniqueKey struct {
+	name string
+	expr string
+}
+
+type table struct {
+	name    string
+	columns []column
+	unique  []uniqueKey
+}
+
+// tables lists the tables in a snapshot in dependency order, so rows can be
+// restored table by table. Derived and private data (milestone events,
+// API keys, watchlists and webhooks) is not included.
+var tables = []table{
+	{name: "celebrities", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "name", kind: kindText},
+		{name: "slug", kind: kindText},
+		{name: "photo_url", kind: kindText, nullable: true},
+		{name: "summary", kind: kindText, nullable: true},
+		{name: "death_date", kind: kindDate, nullable: true},
+		{name: "last_updated", kind: kindTimestamp, nullable: true},
+	}, unique: []uniqueKey{
+		{name: "name", expr: "%[1]s.name"},
+	}},
+	{name: "celebrity_aliases", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "celebrity_id", kind: kindUUID, references: "celebrities"},
+		{name: "alias", kind: kindText},
+		{name: "source", kind: kindText, values: []string{"wikidata", "manual"}},
+		{name: "created_at", kind: kindTimestamp, nullable: true},
+	}, unique: []uniqueKey{
+		{name: "alias", expr: "(%[1]s.celebrity_id, immutable_unaccent(LOWER(%[1]s.alias)))"},
+	}},
+	{name: "works", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "wikidata_id", kind: kindText},
+		{name: "title", kind: kindText},
+		{name: "type", kind: kindText, values: []string{"film", "series", "album", "play", "other"}},
+		{name: "created_at", kind: kindTimestamp, nullable: true},
+	}, unique: []uniqueKey{
+		{name: "wikidata_id", expr: "%[1]s.wikidata_id"},
+	}},
+	{name: "awards", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "celebrity_id", kind: kindUUID, references: "celebrities"},
+		{name: "type", kind: kindText, values: []string{"Emmy", "Grammy", "Oscar", "Tony"}},
+		{name: "year", kind: kindInt},
+		{name: "work", kind: kindText},
+		{name: "category", kind: kindText},
+		{name: "is_winner", kind: kindBool},
+		{name: "ceremony_date", kind: kindDate, nullable: true},
+		{name: "is_upcoming", kind: kindBool},
+		{name: "work_id", kind: kindUUID, nullable: true, references: "works"},
+		{name: "source", kind: kindText, values: []string{"seed", "wikidata", "manual"}},
+		{name: "wikidata_statement_id", kind: kindText, nullable: true},
+		{name: "wikidata_award_id", kind: kindText, nullable: true},
+		{name: "wikidata_work_id", kind: kindText, nullable: true},
+		{name: "fetched_at", kind: kindTimestamp, nullable: true},
+		{name: "scraper_version", kind: kindText, nullable: true},
+		{name: "created_at", kind: kindTimestamp},
+		{name: "updated_at", kind: kindTimestamp},
+	}, unique: []uniqueKey{
+		{name: "natural key (celebrity, type, category, year, work)", expr: "(%[1]s.celebrity_id, %[1]s.type, %[1]s.category, %[1]s.year, %[1]s.work)"},
+	}},
+	{name: "oscar_ceremonies", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "year", kind: kindInt},
+		{name: "ceremony_name", kind: kindText, nullable: true},
+		{name: "ceremony_date", kind: kindDate, nullable: true},
+		{name: "is_complete", kind: kindBool, nullable: true},
+		{name: "created_at", kind: kindTimestamp, nullable: true},
+	}, unique: []uniqueKey{
+		{name: "year", expr: "%[1]s.year"},
+	}},
+	{name: "oscar_categories", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "ceremony_id", kind: kindUUID, nullable: true, references: "oscar_ceremonies"},
+		{name: "name", kind: kindText},
+		{name: "display_order", kind: kindInt, nullable: true},
+		{name: "winner_announced", kind: kindBool, nullable: true},
+	}},
+	{name: "oscar_nominees", columns: []column{
+		{name: "id", kind: kindUUID},
+		{name: "category_id", kind: kindUUID, nullable: true, references: "oscar_categories"},
+		{name: "celebrity_id", kind: kindUUID, nullable: true, references: "celebrities"},
+		{name: "name", kind: kindText},
+		{name: "photo_url", kind: kindText, nullable: true},
+		{name: "work_title", kind: kindText, nullable: true},
+		{name: "is_winner", kind: kindBool, nullable: true},
+		{name: "display_order", kind: kindInt, nullable: true},
+	}},
+}
+
+// TableNames returns the names of the tables in a snapshot, in the order
+// they are restored
+func TableNames() []string {
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = t.name
+	}
+	return names
+}
+
+// findTable returns the table with the given name
+func findTable(name string) (table, bool) {
+	for _, t := range tables {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return table{}, false
+}
+
+func (t table) columnNames() []string {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// check validates a value decoded from JSON (string, json.Number, bool or
+// nil) against the column
+func (c column) check(value any) error {
+	if value == nil {
+		if c.nullable {
+			return nil
+		}
+		return fmt.Errorf("%s is required", c.name)
+	}
+
+	switch c.kind {
+	case kindInt:
+		n, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s must be an integer", c.name)
+		}
+		if _, err := n.Int64(); err != nil {
+			return fmt.Errorf("%s must be an integer", c.name)
+		}
+		return nil
+	case kindBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be true or false", c.name)
+		}
+		return nil
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%s must be a string", c.name)
+	}
+	switch c.kind {
+	case kindUUID:
+		if !uuidPattern.MatchString(s) {
+			return fmt.Errorf("%s is not a UUID: %q", c.name, s)
+		}
+	case kindDate:
+		if _, err := time.Parse(time.DateOnly, s); err != nil {
+			return fmt.Errorf("%s is not a date: %q", c.name, s)
+		}
+	case kindTimestamp:
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			return fmt.Errorf("%s is not an RFC 3339 timestamp: %q", c.name, s)
+		}
+	}
+	if c.values != nil && !slices.Contains(c.values, s) {
+		return fmt.Errorf("%s must be one of %v, got %q", c.name, c.values, s)
+	}
+	return nil
+}
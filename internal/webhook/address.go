@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// reservedPrefixes are special-purpose ranges not covered by the netip
+// predicates that webhooks must not reach
+var reservedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "this network"
+	netip.MustParsePrefix("100.64.0.0/10"), // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"), // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),   // reserved, and broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),  // NAT64, which can embed any IPv4 address
+}
+
+// IsPublicAddr reports whether a webhook may be sent to addr: it must not be
+// loopback, link-local, private, multicast, unspecified or otherwise reserved
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range reservedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPublicHost reports whether a URL host (without port) may be a webhook
+// target. Names other than localhost pass, since they are checked when
+// dialled; literal addresses must be public.
+func IsPublicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return true
+	}
+	return IsPublicAddr(addr)
+}
+
+// dialControl refuses connections to non-public addresses. It runs after DNS
+// resolution, for every address dialled, so a name that resolves, or is
+// later rebound, to an internal address cannot be used to reach it.
+func dialControl(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !IsPublicAddr(addr) {
+		return fmt.Errorf("webhook address %s is not public", addr)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import "testing"
+
+func TestIsPublicHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"example.com", true},
+		{"8.8.8.8", true},
+		{"[2606:4700::1]", true},
+		{"localhost", false},
+		{"LOCALHOST.", false},
+		{"api.localhost", false},
+		{"127.0.0.1", false},
+		{"[::1]", false},
+		{"::ffff:127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"[fd00::1]", false},
+		{"169.254.169.254", false},
+		{"[fe80::1]", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"[64:ff9b::a00:1]", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPublicHost(tt.host); got != tt.want {
+			t.Errorf("IsPublicHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestDialControl(t *testing.T) {
+	if err := dialControl("tcp", "127.0.0.1:80", nil); err == nil {
+		t.Error("dialControl allowed a loopback address")
+	}
+	if err := dialControl("tcp6", "[fe80::1%eth0]:443", nil); err == nil {
+		t.Error("dialControl allowed a link-local address")
+	}
+	if err := dialControl("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("dialControl refused a public address: %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+// Package webhook sends queued webhook deliveries from the outbox.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"egot-tracker/internal/models"
+	"egot-tracker/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the watchlist's webhook secret.
+const (
+	HeaderDeliveryID = "X-EGOT-Delivery"
+	HeaderEvent      = "X-EGOT-Event"
+	HeaderTimestamp  = "X-EGOT-Timestamp"
+	HeaderSignature  = "X-EGOT-Signature"
+)
+
+const (
+	pollInterval   = 5 * time.Second
+	batchSize      = 20
+	requestTimeout = 15 * time.Second
+	// claimLease is how long a claimed delivery is hidden from other
+	// dispatchers. A batch is sent one delivery at a time, so the lease
+	// outlasts a batch in which every request times out.
+	claimLease = batchSize*requestTimeout + time.Minute
+	// maxAttempts is how many times a delivery is tried before it is failed
+	maxAttempts = 8
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+)
+
+// Dispatcher periodically sends due deliveries, retrying failures with
+// exponential backoff
+type Dispatcher struct {
+	repo   *repository.WebhookRepository
+	client *http.Client
+}
+
+func NewDispatcher(repo *repository.WebhookRepository) *Dispatcher {
+	// Webhooks are dialled directly, never through a proxy, so that every
+	// address connected to is checked
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: dialControl}
+	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        100,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	return &Dispatcher{
+		repo:   repo,
+		client: &http.Client{Timeout: requestTimeout, Transport: transport},
+	}
+}
+
+// Run sends due deliveries every pollInterval until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.dispatchDue(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("webhook dispatch failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchDue sends batches of due deliveries until none are left
+func (d *Dispatcher) dispatchDue(ctx context.Context) error {
+	for {
+		deliveries, err := d.repo.ClaimDue(ctx, batchSize, claimLease)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if err := d.deliver(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return nil
+		}
+	}
+}
+
+// deliver sends one delivery and records the attempt
+func (d *Dispatcher) deliver(ctx context.Context, delivery models.WebhookDelivery) error {
+	started := time.Now()
+	statusCode, sendErr := d.send(ctx, delivery)
+
+	attempt := models.WebhookDeliveryAttempt{
+		DeliveryID: delivery.ID,
+		DurationMs: int(time.Since(started).Milliseconds()),
+	}
+	if statusCode != 0 {
+		attempt.StatusCode = pgtype.Int4{Int32: int32(statusCode), Valid: true}
+	}
+	if sendErr != nil {
+		attempt.Error = pgtype.Text{String: sendErr.Error(), Valid: true}
+	}
+
+	status := models.DeliveryDelivered
+	next := time.Now()
+	if sendErr != nil {
+		attempts := delivery.Attempts + 1
+		if attempts >= maxAttempts {
+			status = models.DeliveryFailed
+		} else {
+			status = models.DeliveryPending
+			next = next.Add(Backoff(attempts))
+		}
+	}
+
+	return d.repo.RecordAttempt(ctx, attempt, status, next)
+}
+
+// send posts a delivery to its webhook and returns the response status
+func (d *Dispatcher) send(ctx context.Context, delivery models.WebhookDelivery) (int, error) {
+	if delivery.WebhookURL == "" {
+		return 0, fmt.Errorf("watchlist has no webhook URL")
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.WebhookURL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EGOT-Tracker-Webhooks/1.0")
+	req.Header.Set(HeaderDeliveryID, deliveryID(delivery.ID))
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(delivery.WebhookSecret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with secret.
+// Receivers should recompute it and compare in constant time.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Backoff returns the delay before retrying after the given number of failed
+// attempts: baseBackoff doubled for each attempt, capped at maxBackoff
+func Backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// deliveryID formats a delivery ID in its canonical hyphenated form
+func deliveryID(id pgtype.UUID) string {
+	value, _ := id.Value()
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "json body",
+			secret:    "secret",
+			timestamp: "1700000000",
+			body:      `{"type":"award.won"}`,
+			want:      "14012060b01ca023f30b051bd81c86e48782f9438fc4c3d96d0820d477653248",
+		},
+		{
+			name:      "empty secret and body",
+			secret:    "",
+			timestamp: "0",
+			body:      "",
+			want:      "b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3",
+		},
+		{
+			name:      "multibyte body",
+			secret:    "whsec_abc",
+			timestamp: "1735689600",
+			body:      "café",
+			want:      "0bfe33a689392828f494ac5d20e0d24587274a5b0349a77f5dce9e205b041735",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignDependsOnTimestamp(t *testing.T) {
+	body := []byte(`{"type":"award.won"}`)
+	if Sign("secret", "1700000000", body) == Sign("secret", "1700000001", body) {
+		t.Error("signatures for different timestamps are equal")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{8, 64 * time.Minute},
+		{10, 256 * time.Minute},
+		{11, 6 * time.Hour}, // 512 minutes, capped
+		{1000, 6 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
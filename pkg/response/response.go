@@ -3,10 +3,25 @@ package response
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 )
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// RequestIDHeader carries the ID of a request, assigned by the request ID
+// middleware and echoed in the response
+const RequestIDHeader = "X-Request-ID"
+
+// Problem is an RFC 7807 problem details object, the body of every error
+// response
+type Problem struct {
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	// Code identifies the error to clients and never changes
+	Code      string `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+	// Fields maps invalid request fields to what is wrong with them
+	Fields map[string]string `json:"fields,omitempty"`
 }
 
 func JSON(w http.ResponseWriter, status int, data interface{}) {
@@ -15,6 +30,29 @@ func JSON(w http.ResponseWriter, status int, data interface{}) {
 	json.NewEncoder(w).Encode(data)
 }
 
+// Error responds with a problem whose code is derived from the status, for
+// errors that have no more specific code
 func Error(w http.ResponseWriter, status int, message string) {
-	JSON(w, status, ErrorResponse{Error: message})
+	code := strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+	if status == http.StatusInternalServerError {
+		code = "internal_error"
+	}
+	WriteProblem(w, status, code, message, nil)
+}
+
+// WriteProblem responds with an application/problem+json body. The request
+// ID is taken from the response's X-Request-ID header.
+func WriteProblem(w http.ResponseWriter, status int, code, detail string, fields map[string]string) {
+	problem := Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Code:      code,
+		RequestID: w.Header().Get(RequestIDHeader),
+		Fields:    fields,
+	}
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem)
 }